@@ -0,0 +1,267 @@
+// Package watcher provides mechanisms to detect configuration changes —
+// from a local file, directory, glob pattern, or Redis keyspace
+// notifications — and trigger a reload callback.
+package watcher
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// File watches a file, a directory (recursively), or a glob pattern for
+// changes, and invokes reload whenever a matching path is created or
+// written to, using Options' defaults. For Include/Exclude filtering,
+// Debounce, or RenameGracePeriod tuning, call WithOptions instead.
+type File struct {
+	// Path is the file, directory, or glob pattern to watch. A directory
+	// is watched recursively.
+	Path string
+}
+
+// Options configures the optional behavior of a File watch beyond its
+// defaults.
+type Options struct {
+	// Include, if non-empty, restricts reload-triggering events to paths
+	// whose base name matches at least one of these patterns.
+	Include []string
+	// Exclude skips reload-triggering events for paths whose base name
+	// matches any of these patterns, even if Include also matches.
+	Exclude []string
+	// Debounce coalesces a burst of filesystem events — such as the
+	// write-then-rename sequence of an atomic save — into a single
+	// reload. It defaults to 100ms.
+	Debounce time.Duration
+	// RenameGracePeriod is how long Watch waits for a path removed or
+	// renamed away to reappear before treating it as a real deletion. It
+	// exists because editors like vim save atomically: the original inode
+	// is unlinked and a new one takes its name. It defaults to one second.
+	// It applies only when Path is a single file: a watched directory or
+	// glob base that is removed stops Watch at once, and removed entries
+	// beneath it are ignored.
+	RenameGracePeriod time.Duration
+}
+
+// Watch blocks, using fsnotify to detect changes to Path until ctx is
+// cancelled, Path is permanently removed, or reload returns a non-nil
+// error.
+func (f File) Watch(ctx context.Context, reload func() error) error {
+	return fileWithOptions{path: f.Path}.Watch(ctx, reload)
+}
+
+// WithOptions returns a Watcher that watches the same Path as f but with
+// opts applied.
+func (f File) WithOptions(opts Options) Watcher {
+	return fileWithOptions{path: f.Path, opts: opts}
+}
+
+// fileWithOptions is the Watcher returned by File.WithOptions; it carries
+// the Include/Exclude/Debounce/RenameGracePeriod knobs that File itself
+// cannot, since File must remain a single-field struct to preserve its
+// existing unkeyed-literal construction.
+type fileWithOptions struct {
+	path string
+	opts Options
+}
+
+func (f fileWithOptions) Watch(ctx context.Context, reload func() error) error {
+	// fsnotify names an event by joining the watched directory and the
+	// entry with "/" (a file in "." is reported as "./name"), so Path and
+	// every event name are cleaned before they are compared.
+	pattern := filepath.Clean(f.path)
+	isGlob := isGlobPattern(pattern)
+
+	watchDir := pattern
+	singleFile := ""
+	if isGlob {
+		watchDir = globBase(pattern)
+	} else {
+		fi, err := os.Stat(pattern)
+		if err != nil {
+			return nil
+		}
+		if !fi.IsDir() {
+			singleFile = pattern
+			watchDir = filepath.Dir(pattern)
+		}
+	}
+
+	w, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("watcher: create fsnotify watcher: %w", err)
+	}
+	defer w.Close()
+
+	if err := addRecursive(w, watchDir); err != nil {
+		return fmt.Errorf("watcher: watch %s: %w", watchDir, err)
+	}
+
+	debounce := f.opts.Debounce
+	if debounce <= 0 {
+		debounce = 100 * time.Millisecond
+	}
+	grace := f.opts.RenameGracePeriod
+	if grace <= 0 {
+		grace = time.Second
+	}
+
+	var (
+		debounceTimer *time.Timer
+		debounceC     <-chan time.Time
+		graceTimer    *time.Timer
+		graceC        <-chan time.Time
+	)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case ev, ok := <-w.Events:
+			if !ok {
+				return nil
+			}
+			ev.Name = filepath.Clean(ev.Name)
+			if singleFile != "" && ev.Name != singleFile {
+				continue
+			}
+
+			// A newly created directory is added to the watch regardless
+			// of Include/Exclude, which filter only whether an event
+			// triggers reload — not whether fsnotify keeps watching the
+			// tree beneath it.
+			if ev.Op&fsnotify.Create != 0 {
+				if fi, err := os.Stat(ev.Name); err == nil && fi.IsDir() {
+					_ = addRecursive(w, ev.Name)
+				}
+			}
+
+			if ev.Op&(fsnotify.Remove|fsnotify.Rename) != 0 {
+				if singleFile != "" {
+					graceTimer = time.NewTimer(grace)
+					graceC = graceTimer.C
+					continue
+				}
+				if ev.Name != watchDir {
+					// A child of the watched directory (or a file matching
+					// the glob) being removed or renamed away is not the
+					// thing we're watching going away — it's typically an
+					// editor's atomic-save temp file, and its replacement
+					// will arrive as its own Create event.
+					continue
+				}
+				// The watched directory or glob base itself disappeared
+				// outright: a real deletion, not an atomic-save artifact.
+				return nil
+			}
+			if ev.Op&(fsnotify.Write|fsnotify.Create) == 0 {
+				continue
+			}
+			if graceTimer != nil {
+				graceTimer.Stop()
+				graceTimer, graceC = nil, nil
+			}
+			if !f.opts.matches(ev.Name, pattern, isGlob) {
+				continue
+			}
+
+			if debounceTimer == nil {
+				debounceTimer = time.NewTimer(debounce)
+			} else {
+				if !debounceTimer.Stop() {
+					<-debounceTimer.C
+				}
+				debounceTimer.Reset(debounce)
+			}
+			debounceC = debounceTimer.C
+
+		case <-graceC:
+			// The removed/renamed-away path never reappeared within the
+			// grace period: treat it as a real deletion.
+			return nil
+
+		case <-debounceC:
+			debounceTimer, debounceC = nil, nil
+			if err := reload(); err != nil {
+				return err
+			}
+
+		case rerr, ok := <-w.Errors:
+			if !ok {
+				return nil
+			}
+			_ = rerr
+		}
+	}
+}
+
+// matches reports whether name should trigger a reload: it must match
+// pattern (when watching a glob) and pass Include/Exclude.
+func (o Options) matches(name, pattern string, isGlob bool) bool {
+	if isGlob {
+		if matched, err := filepath.Match(pattern, name); err != nil || !matched {
+			return false
+		}
+	}
+	base := filepath.Base(name)
+	for _, excl := range o.Exclude {
+		if matched, _ := filepath.Match(excl, base); matched {
+			return false
+		}
+	}
+	if len(o.Include) == 0 {
+		return true
+	}
+	for _, incl := range o.Include {
+		if matched, _ := filepath.Match(incl, base); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// isGlobPattern reports whether path contains any glob metacharacters.
+func isGlobPattern(path string) bool {
+	return strings.ContainsAny(path, "*?[")
+}
+
+// globBase returns the deepest directory in pattern that contains no glob
+// metacharacters, so it can be watched directly; fsnotify has no native
+// glob support.
+func globBase(pattern string) string {
+	parts := strings.Split(filepath.ToSlash(pattern), "/")
+	var base []string
+	for _, p := range parts {
+		if isGlobPattern(p) {
+			break
+		}
+		base = append(base, p)
+	}
+	if len(base) == 0 {
+		return "."
+	}
+	return filepath.FromSlash(strings.Join(base, "/"))
+}
+
+// addRecursive adds dir and every subdirectory beneath it to w. fsnotify
+// only watches a directory's immediate entries, so nested directories must
+// be added individually to watch a tree recursively.
+func addRecursive(w *fsnotify.Watcher, dir string) error {
+	return filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return w.Add(path)
+		}
+		return nil
+	})
+}
+
+var _ Watcher = File{}
+var _ Watcher = fileWithOptions{}
@@ -0,0 +1,171 @@
+package watcher
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileWithOptions(t *testing.T) {
+	t.Run("directory recursive with include filter", func(t *testing.T) {
+		t.Parallel()
+		dir, err := ioutil.TempDir(".", "watchdir")
+		assert.NoError(t, err)
+		defer os.RemoveAll(dir)
+
+		ch := make(chan struct{}, 1)
+		w := File{dir}.WithOptions(Options{
+			Include:  []string{"*.yaml"},
+			Debounce: 10 * time.Millisecond,
+		})
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		go w.Watch(ctx, func() error {
+			ch <- struct{}{}
+			return nil
+		})
+		time.Sleep(500 * time.Millisecond)
+
+		// A new subdirectory's name does not match *.yaml, but it must
+		// still be watched so files created under it later are detected.
+		sub := filepath.Join(dir, "sub")
+		assert.NoError(t, os.Mkdir(sub, 0o755))
+		time.Sleep(500 * time.Millisecond)
+
+		assert.NoError(t, ioutil.WriteFile(filepath.Join(sub, "ignored.txt"), []byte("x"), os.ModePerm))
+		select {
+		case <-ch:
+			t.Fatal("reload fired for a file excluded by Include")
+		case <-time.After(300 * time.Millisecond):
+		}
+
+		assert.NoError(t, ioutil.WriteFile(filepath.Join(sub, "config.yaml"), []byte("x"), os.ModePerm))
+		select {
+		case <-ch:
+		case <-time.After(2 * time.Second):
+			t.Fatal("expected reload for a matching file under a newly created subdirectory")
+		}
+	})
+
+	t.Run("exclude takes precedence over include", func(t *testing.T) {
+		t.Parallel()
+		dir, err := ioutil.TempDir(".", "watchdir")
+		assert.NoError(t, err)
+		defer os.RemoveAll(dir)
+
+		ch := make(chan struct{}, 1)
+		w := File{dir}.WithOptions(Options{
+			Include:  []string{"*.yaml"},
+			Exclude:  []string{"secret.yaml"},
+			Debounce: 10 * time.Millisecond,
+		})
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		go w.Watch(ctx, func() error {
+			ch <- struct{}{}
+			return nil
+		})
+		time.Sleep(500 * time.Millisecond)
+
+		assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "secret.yaml"), []byte("x"), os.ModePerm))
+		select {
+		case <-ch:
+			t.Fatal("reload fired for a file excluded by Exclude")
+		case <-time.After(300 * time.Millisecond):
+		}
+	})
+
+	t.Run("two successive edits both reload", func(t *testing.T) {
+		t.Parallel()
+		dir, err := ioutil.TempDir(".", "watchdir")
+		assert.NoError(t, err)
+		defer os.RemoveAll(dir)
+
+		path := filepath.Join(dir, "config.yaml")
+		assert.NoError(t, ioutil.WriteFile(path, []byte("x"), os.ModePerm))
+
+		ch := make(chan struct{}, 1)
+		w := File{dir}.WithOptions(Options{
+			Include:  []string{"*.yaml"},
+			Debounce: 10 * time.Millisecond,
+		})
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		go w.Watch(ctx, func() error {
+			ch <- struct{}{}
+			return nil
+		})
+		time.Sleep(500 * time.Millisecond)
+
+		assert.NoError(t, ioutil.WriteFile(path, []byte("y"), os.ModePerm))
+		select {
+		case <-ch:
+		case <-time.After(2 * time.Second):
+			t.Fatal("expected reload for the first edit")
+		}
+
+		// The debounce timer must be reset to nil once it fires, or a
+		// second matching event blocks forever waiting on a drained timer.
+		assert.NoError(t, ioutil.WriteFile(path, []byte("z"), os.ModePerm))
+		select {
+		case <-ch:
+		case <-time.After(2 * time.Second):
+			t.Fatal("expected reload for the second edit")
+		}
+	})
+
+	t.Run("atomic rename of a watched directory's child does not stop the watcher", func(t *testing.T) {
+		t.Parallel()
+		dir, err := ioutil.TempDir(".", "watchdir")
+		assert.NoError(t, err)
+		defer os.RemoveAll(dir)
+
+		path := filepath.Join(dir, "config.yaml")
+		assert.NoError(t, ioutil.WriteFile(path, []byte("x"), os.ModePerm))
+
+		ch := make(chan struct{}, 1)
+		w := File{dir}.WithOptions(Options{
+			Include:  []string{"*.yaml"},
+			Debounce: 10 * time.Millisecond,
+		})
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		done := make(chan error, 1)
+		go func() {
+			done <- w.Watch(ctx, func() error {
+				ch <- struct{}{}
+				return nil
+			})
+		}()
+		time.Sleep(500 * time.Millisecond)
+
+		// Simulate an editor's atomic save: write the new content to a
+		// temp file, then rename it over the watched file. The rename
+		// emits a Remove/Rename event for path itself, which must not be
+		// mistaken for the watched directory disappearing.
+		tmp := filepath.Join(dir, "config.yaml.tmp")
+		assert.NoError(t, ioutil.WriteFile(tmp, []byte("y"), os.ModePerm))
+		assert.NoError(t, os.Rename(tmp, path))
+
+		select {
+		case <-ch:
+		case <-time.After(2 * time.Second):
+			t.Fatal("expected reload after the atomic rename")
+		}
+
+		select {
+		case err := <-done:
+			t.Fatalf("Watch returned early after a child rename: %v", err)
+		case <-time.After(300 * time.Millisecond):
+		}
+	})
+}
@@ -0,0 +1,120 @@
+package watcher
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/DoNewsCode/std/pkg/otredis"
+	"github.com/go-redis/redis/v8"
+)
+
+// Redis watches for Redis keyevent notifications and invokes the reload
+// callback when a key matching Pattern is touched. It requires Redis'
+// keyspace notification feature; see AutoEnable to have Redis turn it on
+// automatically.
+type Redis struct {
+	// Maker creates the redis.UniversalClient used to subscribe.
+	Maker otredis.Maker
+	// Name is the configuration entry passed to Maker.Make.
+	Name string
+	// DB is the database number the notifications are scoped to.
+	DB int
+	// Pattern is a filepath.Match glob matched against the key (not the
+	// channel) that triggers a reload, e.g. "myapp:config:*".
+	Pattern string
+	// AutoEnable, when true, issues `CONFIG SET notify-keyspace-events KEA`
+	// on startup so the caller does not have to configure it out of band.
+	AutoEnable bool
+	// Debounce coalesces a burst of matching events into a single reload.
+	// It defaults to one second.
+	Debounce time.Duration
+}
+
+// Watch subscribes to Redis keyevent notifications and invokes reload when
+// a matching key changes. It reconnects with exponential backoff on pubsub
+// disconnect and returns when ctx is cancelled or reload returns an error.
+func (r Redis) Watch(ctx context.Context, reload func() error) error {
+	client, err := r.Maker.Make(r.Name)
+	if err != nil {
+		return fmt.Errorf("watcher: redis client %s: %w", r.Name, err)
+	}
+
+	if r.AutoEnable {
+		if err := client.ConfigSet(ctx, "notify-keyspace-events", "KEA").Err(); err != nil {
+			return fmt.Errorf("watcher: enable keyspace notifications: %w", err)
+		}
+	}
+
+	debounce := r.Debounce
+	if debounce <= 0 {
+		debounce = time.Second
+	}
+
+	backoff := 100 * time.Millisecond
+	const maxBackoff = 30 * time.Second
+	for {
+		err := r.subscribeOnce(ctx, client, reload, debounce)
+		if err != nil {
+			return err
+		}
+		if ctx.Err() != nil {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(backoff):
+		}
+		if backoff *= 2; backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+}
+
+// subscribeOnce runs a single pubsub session until it disconnects, ctx is
+// cancelled, or reload returns an error. A nil error with a live ctx means
+// the caller should reconnect.
+func (r Redis) subscribeOnce(ctx context.Context, client redis.UniversalClient, reload func() error, debounce time.Duration) error {
+	pubsub := client.PSubscribe(ctx, fmt.Sprintf("__keyevent@%d__:*", r.DB))
+	defer pubsub.Close()
+
+	if _, err := pubsub.Receive(ctx); err != nil {
+		return nil
+	}
+
+	ch := pubsub.Channel()
+	var timer *time.Timer
+	var timerC <-chan time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case msg, ok := <-ch:
+			if !ok {
+				return nil
+			}
+			matched, err := filepath.Match(r.Pattern, msg.Payload)
+			if err != nil || !matched {
+				continue
+			}
+			if timer == nil {
+				timer = time.NewTimer(debounce)
+			} else {
+				if !timer.Stop() {
+					<-timer.C
+				}
+				timer.Reset(debounce)
+			}
+			timerC = timer.C
+		case <-timerC:
+			timer, timerC = nil, nil
+			if err := reload(); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+var _ Watcher = Redis{}
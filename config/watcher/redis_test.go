@@ -0,0 +1,78 @@
+package watcher
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeMaker implements otredis.Maker against a single fixed client, so
+// tests don't need the full otredis provider wiring.
+type fakeMaker struct {
+	client redis.UniversalClient
+}
+
+func (f fakeMaker) Make(name string) (redis.UniversalClient, error) {
+	return f.client, nil
+}
+
+func (f fakeMaker) Health(ctx context.Context, name string) error {
+	return f.client.Ping(ctx).Err()
+}
+
+func TestRedisWatch(t *testing.T) {
+	s, err := miniredis.Run()
+	assert.NoError(t, err)
+	defer s.Close()
+
+	client := redis.NewClient(&redis.Options{Addr: s.Addr()})
+	defer client.Close()
+
+	r := Redis{
+		Maker:    fakeMaker{client: client},
+		Name:     "default",
+		DB:       0,
+		Pattern:  "myapp:config:*",
+		Debounce: 10 * time.Millisecond,
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ch := make(chan struct{}, 1)
+	go r.Watch(ctx, func() error {
+		ch <- struct{}{}
+		return nil
+	})
+
+	// Give subscribeOnce time to establish its PSubscribe before publishing.
+	time.Sleep(200 * time.Millisecond)
+
+	s.Publish("__keyevent@0__:set", "myapp:other:key")
+	select {
+	case <-ch:
+		t.Fatal("reload fired for a key not matching Pattern")
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	s.Publish("__keyevent@0__:set", "myapp:config:db")
+	select {
+	case <-ch:
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected reload for a key matching Pattern")
+	}
+
+	// A second matching keyevent must also trigger a reload: the debounce
+	// timer must not be left in a state that blocks forever after firing
+	// once.
+	s.Publish("__keyevent@0__:set", "myapp:config:db")
+	select {
+	case <-ch:
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected a second reload for a second matching key")
+	}
+}
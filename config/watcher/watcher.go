@@ -0,0 +1,47 @@
+package watcher
+
+import "context"
+
+// Watcher triggers a reload callback when it detects a change in whatever
+// it observes, be it a file or a Redis key.
+type Watcher interface {
+	Watch(ctx context.Context, reload func() error) error
+}
+
+// Multi fans out a single reload callback across many Watchers, so a
+// config reload can be driven by any of several sources — for example
+// either a config file edit or a Redis-published signal.
+type Multi []Watcher
+
+// Watch starts every Watcher in m concurrently, invoking reload whenever
+// any of them detects a change. The first Watcher to stop (by returning,
+// whether with an error or not) causes the rest to be cancelled; Watch
+// then returns that Watcher's error.
+func (m Multi) Watch(ctx context.Context, reload func() error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	errCh := make(chan error, len(m))
+	for _, w := range m {
+		w := w
+		go func() {
+			errCh <- w.Watch(ctx, reload)
+		}()
+	}
+
+	var (
+		err   error
+		first = true
+	)
+	for range m {
+		e := <-errCh
+		if first {
+			err = e
+			cancel()
+			first = false
+		}
+	}
+	return err
+}
+
+var _ Watcher = Multi{}
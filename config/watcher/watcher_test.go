@@ -0,0 +1,99 @@
+package watcher
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeWatcher is a Watcher whose Watch blocks until triggered (closing
+// trigger) or ctx is cancelled, invoking reload and returning err.
+type fakeWatcher struct {
+	trigger chan struct{}
+	err     error
+}
+
+func (f fakeWatcher) Watch(ctx context.Context, reload func() error) error {
+	select {
+	case <-ctx.Done():
+		return nil
+	case <-f.trigger:
+		if err := reload(); err != nil {
+			return err
+		}
+		return f.err
+	}
+}
+
+func TestMulti(t *testing.T) {
+	t.Run("reload fires from any watcher", func(t *testing.T) {
+		t.Parallel()
+		a := fakeWatcher{trigger: make(chan struct{})}
+		b := fakeWatcher{trigger: make(chan struct{})}
+		m := Multi{a, b}
+
+		called := make(chan struct{}, 1)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		done := make(chan error, 1)
+		go func() {
+			done <- m.Watch(ctx, func() error {
+				called <- struct{}{}
+				return nil
+			})
+		}()
+
+		close(b.trigger)
+		select {
+		case <-called:
+		case <-time.After(time.Second):
+			t.Fatal("expected reload from b")
+		}
+		cancel()
+		<-done
+	})
+
+	t.Run("first watcher to stop without an error cancels the rest", func(t *testing.T) {
+		t.Parallel()
+		a := fakeWatcher{trigger: make(chan struct{})}
+		b := fakeWatcher{trigger: make(chan struct{})}
+		m := Multi{a, b}
+
+		done := make(chan error, 1)
+		go func() {
+			done <- m.Watch(context.Background(), func() error { return nil })
+		}()
+
+		close(a.trigger)
+		select {
+		case err := <-done:
+			assert.NoError(t, err)
+		case <-time.After(time.Second):
+			t.Fatal("expected Watch to return once a stopped, without waiting for b")
+		}
+	})
+
+	t.Run("first error cancels the rest", func(t *testing.T) {
+		t.Parallel()
+		a := fakeWatcher{trigger: make(chan struct{}), err: errors.New("boom")}
+		b := fakeWatcher{trigger: make(chan struct{})}
+		m := Multi{a, b}
+
+		done := make(chan error, 1)
+		go func() {
+			done <- m.Watch(context.Background(), func() error { return nil })
+		}()
+
+		close(a.trigger)
+		select {
+		case err := <-done:
+			assert.EqualError(t, err, "boom")
+		case <-time.After(time.Second):
+			t.Fatal("expected Watch to return a's error")
+		}
+	})
+}
@@ -0,0 +1,100 @@
+package ots3
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// LocalStorage implements Storage on top of the local filesystem. It is
+// meant as a drop-in replacement for Manager in tests and local development,
+// where talking to a real S3 endpoint is undesirable.
+type LocalStorage struct {
+	// BaseDir is the directory objects are stored under. It is created on
+	// first use if missing.
+	BaseDir string
+	// BaseURL is prepended to object names when building the URL returned
+	// by Upload. It defaults to "file://" + BaseDir. Presign does not use
+	// it and always returns a "file://" URL to the object's path.
+	BaseURL string
+}
+
+// NewLocalStorage creates a LocalStorage rooted at dir.
+func NewLocalStorage(dir string) *LocalStorage {
+	return &LocalStorage{BaseDir: dir}
+}
+
+func (l *LocalStorage) path(name string) string {
+	return filepath.Join(l.BaseDir, filepath.FromSlash(name))
+}
+
+func (l *LocalStorage) baseURL() string {
+	if l.BaseURL != "" {
+		return l.BaseURL
+	}
+	return "file://" + l.BaseDir
+}
+
+// Upload writes the content read from r to BaseDir/name.
+func (l *LocalStorage) Upload(ctx context.Context, name string, r io.Reader) (string, error) {
+	p := l.path(name)
+	if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+		return "", fmt.Errorf("ots3: create directory for %s: %w", name, err)
+	}
+	f, err := os.Create(p)
+	if err != nil {
+		return "", fmt.Errorf("ots3: create %s: %w", name, err)
+	}
+	defer f.Close()
+	if _, err := io.Copy(f, r); err != nil {
+		return "", fmt.Errorf("ots3: write %s: %w", name, err)
+	}
+	return l.baseURL() + "/" + name, nil
+}
+
+// Download opens BaseDir/name for reading.
+func (l *LocalStorage) Download(ctx context.Context, name string) (io.ReadCloser, error) {
+	f, err := os.Open(l.path(name))
+	if err != nil {
+		return nil, fmt.Errorf("ots3: download %s: %w", name, err)
+	}
+	return f, nil
+}
+
+// Delete removes BaseDir/name.
+func (l *LocalStorage) Delete(ctx context.Context, name string) error {
+	if err := os.Remove(l.path(name)); err != nil {
+		return fmt.Errorf("ots3: delete %s: %w", name, err)
+	}
+	return nil
+}
+
+// Stat returns metadata about BaseDir/name.
+func (l *LocalStorage) Stat(ctx context.Context, name string) (Info, error) {
+	fi, err := os.Stat(l.path(name))
+	if err != nil {
+		return Info{}, fmt.Errorf("ots3: stat %s: %w", name, err)
+	}
+	return Info{
+		Name:         name,
+		Size:         fi.Size(),
+		LastModified: fi.ModTime(),
+	}, nil
+}
+
+// Presign returns a "file://" URL to the object. Expiry is not enforced
+// since the filesystem has no concept of a signed, time-limited request;
+// the parameter exists purely to satisfy the Storage interface.
+func (l *LocalStorage) Presign(ctx context.Context, method string, name string, expires time.Duration) (string, error) {
+	if _, err := os.Stat(l.path(name)); err != nil && method == "GET" {
+		return "", fmt.Errorf("ots3: presign get %s: %w", name, err)
+	}
+	u := url.URL{Scheme: "file", Path: l.path(name)}
+	return u.String(), nil
+}
+
+var _ Storage = (*LocalStorage)(nil)
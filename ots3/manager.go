@@ -0,0 +1,101 @@
+package ots3
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	"github.com/opentracing/opentracing-go"
+)
+
+// DefaultPartSize is the size of each part in a multipart upload when no
+// Option overrides it. It matches the S3 minimum part size.
+const DefaultPartSize = 5 * 1024 * 1024
+
+// DefaultConcurrency is how many parts are uploaded in parallel when no
+// Option overrides it.
+const DefaultConcurrency = 4
+
+// DefaultMaxRetries is how many times a failed part upload is retried
+// before the whole upload is aborted.
+const DefaultMaxRetries = 3
+
+// Manager talks to an S3 compatible endpoint using minio-go. It implements
+// Storage.
+type Manager struct {
+	core   *minio.Core
+	client *minio.Client
+	region string
+	bucket string
+
+	partSize    int64
+	concurrency int
+	maxRetries  int
+
+	tracer opentracing.Tracer
+}
+
+// Option configures a Manager returned by NewManager.
+type Option func(*Manager)
+
+// WithPartSize sets the size of each part in a multipart upload.
+func WithPartSize(size int64) Option {
+	return func(m *Manager) { m.partSize = size }
+}
+
+// WithConcurrency sets how many parts are uploaded in parallel.
+func WithConcurrency(n int) Option {
+	return func(m *Manager) { m.concurrency = n }
+}
+
+// WithMaxRetries sets how many times a failed part upload is retried.
+func WithMaxRetries(n int) Option {
+	return func(m *Manager) { m.maxRetries = n }
+}
+
+// WithTracer attaches an opentracing.Tracer used to create a span around
+// every part upload.
+func WithTracer(tracer opentracing.Tracer) Option {
+	return func(m *Manager) { m.tracer = tracer }
+}
+
+// NewManager creates a Manager that talks to the given S3 compatible
+// endpoint. endpoint may optionally carry a "https://" or "http://" scheme;
+// a bare "host:port" with no scheme is treated as plain HTTP, matching a
+// local MinIO's default configuration.
+func NewManager(accessKey, secretKey, endpoint, region, bucket string, opts ...Option) *Manager {
+	secure := false
+	host := endpoint
+	if strings.Contains(endpoint, "://") {
+		if u, err := url.Parse(endpoint); err == nil && u.Host != "" {
+			host = u.Host
+			secure = u.Scheme != "http"
+		}
+	}
+	host = strings.TrimSuffix(host, "/")
+
+	client, err := minio.New(host, &minio.Options{
+		Creds:  credentials.NewStaticV4(accessKey, secretKey, ""),
+		Secure: secure,
+		Region: region,
+	})
+	if err != nil {
+		panic(err)
+	}
+	core := &minio.Core{Client: client}
+
+	m := &Manager{
+		core:        core,
+		client:      client,
+		region:      region,
+		bucket:      bucket,
+		partSize:    DefaultPartSize,
+		concurrency: DefaultConcurrency,
+		maxRetries:  DefaultMaxRetries,
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
@@ -0,0 +1,44 @@
+package ots3
+
+import "testing"
+
+func TestNewManagerScheme(t *testing.T) {
+	cases := []struct {
+		name     string
+		endpoint string
+		secure   bool
+	}{
+		{"bare host:port defaults to plain HTTP", "127.0.0.1:9000", false},
+		{"explicit http scheme", "http://127.0.0.1:9000", false},
+		{"explicit https scheme", "https://s3.amazonaws.com", true},
+	}
+	for _, c := range cases {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			m := NewManager("ak", "sk", c.endpoint, "", "bucket")
+			if got := m.client.EndpointURL().Scheme == "https"; got != c.secure {
+				t.Fatalf("endpoint %q: got secure=%v, want %v", c.endpoint, got, c.secure)
+			}
+		})
+	}
+}
+
+func TestManagerPublicURLScheme(t *testing.T) {
+	cases := []struct {
+		name     string
+		endpoint string
+		wantURL  string
+	}{
+		{"plain http endpoint", "127.0.0.1:9000", "http://127.0.0.1:9000/bucket/foo.png"},
+		{"explicit https endpoint", "https://s3.amazonaws.com", "https://s3.amazonaws.com/bucket/foo.png"},
+	}
+	for _, c := range cases {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			m := NewManager("ak", "sk", c.endpoint, "", "bucket")
+			if got := m.publicURL("foo.png"); got != c.wantURL {
+				t.Fatalf("endpoint %q: got %s, want %s", c.endpoint, got, c.wantURL)
+			}
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package ots3
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	partUploadBytes = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "ots3",
+		Name:      "part_upload_bytes_total",
+		Help:      "Total bytes uploaded across all multipart upload parts.",
+	}, []string{"bucket"})
+
+	partUploadFailures = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "ots3",
+		Name:      "part_upload_failures_total",
+		Help:      "Total multipart upload parts that failed after exhausting retries.",
+	}, []string{"bucket"})
+
+	partUploadLatency = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "ots3",
+		Name:      "part_upload_latency_seconds",
+		Help:      "Latency of a single multipart upload part, including retries.",
+	}, []string{"bucket"})
+)
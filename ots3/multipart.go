@@ -0,0 +1,169 @@
+package ots3
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/opentracing/opentracing-go"
+)
+
+// partResult is the outcome of uploading a single part.
+type partResult struct {
+	part minio.CompletePart
+	err  error
+}
+
+// uploadMultipart reads r sequentially into partSize chunks and uploads them
+// to bucket/name concurrently using a pool of m.concurrency workers. Each
+// part is retried with exponential backoff up to m.maxRetries times before
+// the whole upload is aborted.
+func (m *Manager) uploadMultipart(ctx context.Context, bucket, name string, r io.Reader) error {
+	uploadID, err := m.core.NewMultipartUpload(ctx, bucket, name, minio.PutObjectOptions{})
+	if err != nil {
+		return fmt.Errorf("ots3: initiate multipart upload for %s: %w", name, err)
+	}
+
+	type job struct {
+		partNumber int
+		data       []byte
+	}
+
+	jobs := make(chan job)
+	results := make(chan partResult)
+
+	var wg sync.WaitGroup
+	for i := 0; i < m.concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				results <- m.uploadPart(ctx, bucket, name, uploadID, j.partNumber, j.data)
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	readErrCh := make(chan error, 1)
+	go func() {
+		defer close(jobs)
+		buf := make([]byte, m.partSize)
+		partNumber := 1
+		for {
+			n, rerr := io.ReadFull(r, buf)
+			if n > 0 {
+				data := make([]byte, n)
+				copy(data, buf[:n])
+				jobs <- job{partNumber: partNumber, data: data}
+				partNumber++
+			}
+			if rerr == io.EOF || rerr == io.ErrUnexpectedEOF {
+				readErrCh <- nil
+				return
+			}
+			if rerr != nil {
+				readErrCh <- rerr
+				return
+			}
+		}
+	}()
+
+	var (
+		parts     []minio.CompletePart
+		uploadErr error
+	)
+	for res := range results {
+		if res.err != nil && uploadErr == nil {
+			uploadErr = res.err
+			continue
+		}
+		if res.err == nil {
+			parts = append(parts, res.part)
+		}
+	}
+	if rerr := <-readErrCh; rerr != nil && uploadErr == nil {
+		uploadErr = rerr
+	}
+
+	if uploadErr != nil {
+		_ = m.core.AbortMultipartUpload(ctx, bucket, name, uploadID)
+		return fmt.Errorf("ots3: multipart upload %s failed: %w", name, uploadErr)
+	}
+
+	sortParts(parts)
+	if _, err := m.core.CompleteMultipartUpload(ctx, bucket, name, uploadID, parts, minio.PutObjectOptions{}); err != nil {
+		return fmt.Errorf("ots3: complete multipart upload for %s: %w", name, err)
+	}
+	return nil
+}
+
+// uploadPart uploads a single part, retrying with exponential backoff on
+// failure, and records the span and metrics for the attempt.
+func (m *Manager) uploadPart(ctx context.Context, bucket, name, uploadID string, partNumber int, data []byte) partResult {
+	var span opentracing.Span
+	if m.tracer != nil {
+		span, ctx = opentracing.StartSpanFromContextWithTracer(ctx, m.tracer, "ots3.uploadPart")
+		span.SetTag("bucket", bucket)
+		span.SetTag("key", name)
+		span.SetTag("part", partNumber)
+		defer span.Finish()
+	}
+
+	start := time.Now()
+	var (
+		objPart minio.ObjectPart
+		err     error
+	)
+	backoff := 100 * time.Millisecond
+	for attempt := 0; attempt <= m.maxRetries; attempt++ {
+		objPart, err = m.core.PutObjectPart(ctx, bucket, name, uploadID, partNumber, bytes.NewReader(data), int64(len(data)), minio.PutObjectPartOptions{})
+		if err == nil {
+			break
+		}
+		if attempt == m.maxRetries {
+			break
+		}
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			err = ctx.Err()
+			attempt = m.maxRetries
+		case <-timer.C:
+		}
+		backoff *= 2
+	}
+
+	partUploadLatency.WithLabelValues(bucket).Observe(time.Since(start).Seconds())
+	if err != nil {
+		partUploadFailures.WithLabelValues(bucket).Inc()
+		if span != nil {
+			span.SetTag("error", true)
+		}
+		return partResult{err: fmt.Errorf("part %d: %w", partNumber, err)}
+	}
+	partUploadBytes.WithLabelValues(bucket).Add(float64(len(data)))
+
+	return partResult{part: minio.CompletePart{
+		PartNumber: partNumber,
+		ETag:       objPart.ETag,
+	}}
+}
+
+// sortParts orders parts by PartNumber, required by S3 before completing a
+// multipart upload.
+func sortParts(parts []minio.CompletePart) {
+	for i := 1; i < len(parts); i++ {
+		for j := i; j > 0 && parts[j].PartNumber < parts[j-1].PartNumber; j-- {
+			parts[j], parts[j-1] = parts[j-1], parts[j]
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package ots3
+
+import (
+	"fmt"
+
+	"github.com/DoNewsCode/std/pkg/config"
+	"github.com/DoNewsCode/std/pkg/contract"
+	"github.com/DoNewsCode/std/pkg/di"
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/opentracing/opentracing-go"
+)
+
+// s3Conf is the shape of a single named entry under the "s3" configuration
+// key.
+type s3Conf struct {
+	AccessKey   string `json:"accessKey"`
+	SecretKey   string `json:"secretKey"`
+	Endpoint    string `json:"endpoint"`
+	Region      string `json:"region"`
+	Bucket      string `json:"bucket"`
+	PartSize    int64  `json:"partSize"`
+	Concurrency int    `json:"concurrency"`
+	MaxRetries  int    `json:"maxRetries"`
+}
+
+// S3In is the injection parameter for Provide.
+type S3In struct {
+	di.In
+
+	Logger log.Logger
+	Conf   contract.ConfigAccessor
+	Tracer opentracing.Tracer `optional:"true"`
+}
+
+// S3Out is the result of Provide.
+type S3Out struct {
+	di.Out
+
+	Maker          Maker
+	Factory        Factory
+	Manager        *Manager
+	ExportedConfig []config.ExportedConfig `group:"config,flatten"`
+}
+
+// Maker creates Storage backends using a specific configuration entry.
+type Maker interface {
+	Make(name string) (Storage, error)
+}
+
+// Factory is a *di.Factory that creates Storage using a specific
+// configuration entry under the "s3" configuration key.
+type Factory struct {
+	*di.Factory
+}
+
+// Make creates a Storage using a specific configuration entry.
+func (f Factory) Make(name string) (Storage, error) {
+	storage, err := f.Factory.Make(name)
+	if err != nil {
+		return nil, err
+	}
+	return storage.(Storage), nil
+}
+
+// Provide creates a Factory and the default *Manager. It is a valid
+// dependency for package core.
+func Provide(p S3In) (S3Out, func()) {
+	var confs map[string]s3Conf
+	if err := p.Conf.Unmarshal("s3", &confs); err != nil {
+		level.Warn(p.Logger).Log("err", err)
+	}
+
+	factory := di.NewFactory(func(name string) (di.Pair, error) {
+		conf, ok := confs[name]
+		if !ok {
+			return di.Pair{}, fmt.Errorf("s3 configuration %s not valid", name)
+		}
+		var opts []Option
+		if conf.PartSize > 0 {
+			opts = append(opts, WithPartSize(conf.PartSize))
+		}
+		if conf.Concurrency > 0 {
+			opts = append(opts, WithConcurrency(conf.Concurrency))
+		}
+		if conf.MaxRetries > 0 {
+			opts = append(opts, WithMaxRetries(conf.MaxRetries))
+		}
+		if p.Tracer != nil {
+			opts = append(opts, WithTracer(p.Tracer))
+		}
+		manager := NewManager(conf.AccessKey, conf.SecretKey, conf.Endpoint, conf.Region, conf.Bucket, opts...)
+		return di.Pair{
+			Conn:   manager,
+			Closer: func() {},
+		}, nil
+	})
+	s3Factory := Factory{factory}
+	out := S3Out{
+		Maker:          s3Factory,
+		Factory:        s3Factory,
+		ExportedConfig: provideConfig(),
+	}
+	defaultManager, _ := s3Factory.Make("default")
+	if m, ok := defaultManager.(*Manager); ok {
+		out.Manager = m
+	}
+	return out, s3Factory.Close
+}
+
+// provideConfig exports the default s3 configuration.
+func provideConfig() []config.ExportedConfig {
+	return []config.ExportedConfig{
+		{
+			Owner: "ots3",
+			Data: map[string]interface{}{
+				"s3": map[string]map[string]interface{}{
+					"default": {
+						"accessKey":   "",
+						"secretKey":   "",
+						"endpoint":    "127.0.0.1:9000",
+						"region":      "",
+						"bucket":      "",
+						"partSize":    DefaultPartSize,
+						"concurrency": DefaultConcurrency,
+						"maxRetries":  DefaultMaxRetries,
+					},
+				},
+			},
+			Comment: "The configuration of s3 compatible storage clients",
+		},
+	}
+}
@@ -0,0 +1,108 @@
+package ots3
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path/filepath"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+)
+
+// CreateBucket creates a bucket if it does not already exist.
+func (m *Manager) CreateBucket(ctx context.Context, bucket string) error {
+	exists, err := m.client.BucketExists(ctx, bucket)
+	if err != nil {
+		return fmt.Errorf("ots3: check bucket %s: %w", bucket, err)
+	}
+	if exists {
+		return nil
+	}
+	if err := m.client.MakeBucket(ctx, bucket, minio.MakeBucketOptions{Region: m.region}); err != nil {
+		return fmt.Errorf("ots3: create bucket %s: %w", bucket, err)
+	}
+	return nil
+}
+
+// Upload streams r to the configured bucket under name, splitting it into
+// parts and uploading them concurrently via Manager's multipart uploader. It
+// returns the public URL of the stored object.
+func (m *Manager) Upload(ctx context.Context, name string, r io.Reader) (string, error) {
+	if err := m.uploadMultipart(ctx, m.bucket, name, r); err != nil {
+		return "", err
+	}
+	return m.publicURL(name), nil
+}
+
+// publicURL builds the URL at which name is reachable within m.bucket,
+// using the scheme and host the client was actually configured with.
+func (m *Manager) publicURL(name string) string {
+	endpoint := m.client.EndpointURL()
+	return fmt.Sprintf("%s://%s/%s/%s", endpoint.Scheme, endpoint.Host, m.bucket, name)
+}
+
+// Download opens the object stored under name. The caller must close the
+// returned reader.
+func (m *Manager) Download(ctx context.Context, name string) (io.ReadCloser, error) {
+	obj, err := m.client.GetObject(ctx, m.bucket, name, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("ots3: download %s: %w", name, err)
+	}
+	return obj, nil
+}
+
+// Delete removes the object stored under name.
+func (m *Manager) Delete(ctx context.Context, name string) error {
+	if err := m.client.RemoveObject(ctx, m.bucket, name, minio.RemoveObjectOptions{}); err != nil {
+		return fmt.Errorf("ots3: delete %s: %w", name, err)
+	}
+	return nil
+}
+
+// Stat returns metadata about the object stored under name.
+func (m *Manager) Stat(ctx context.Context, name string) (Info, error) {
+	info, err := m.client.StatObject(ctx, m.bucket, name, minio.StatObjectOptions{})
+	if err != nil {
+		return Info{}, fmt.Errorf("ots3: stat %s: %w", name, err)
+	}
+	return Info{
+		Name:         filepath.Base(info.Key),
+		Size:         info.Size,
+		ContentType:  info.ContentType,
+		LastModified: info.LastModified,
+	}, nil
+}
+
+// Presign returns a time-limited URL for method ("GET" or "PUT") against the
+// object stored under name.
+func (m *Manager) Presign(ctx context.Context, method string, name string, expires time.Duration) (string, error) {
+	switch method {
+	case "GET":
+		return m.PresignGet(ctx, name, expires)
+	case "PUT":
+		return m.PresignPut(ctx, name, expires)
+	default:
+		return "", fmt.Errorf("ots3: unsupported presign method %s", method)
+	}
+}
+
+// PresignGet returns a time-limited URL that can be used to download the
+// object stored under name without further authentication.
+func (m *Manager) PresignGet(ctx context.Context, name string, expires time.Duration) (string, error) {
+	u, err := m.client.PresignedGetObject(ctx, m.bucket, name, expires, nil)
+	if err != nil {
+		return "", fmt.Errorf("ots3: presign get %s: %w", name, err)
+	}
+	return u.String(), nil
+}
+
+// PresignPut returns a time-limited URL that can be used to upload an object
+// under name without further authentication.
+func (m *Manager) PresignPut(ctx context.Context, name string, expires time.Duration) (string, error) {
+	u, err := m.client.PresignedPutObject(ctx, m.bucket, name, expires)
+	if err != nil {
+		return "", fmt.Errorf("ots3: presign put %s: %w", name, err)
+	}
+	return u.String(), nil
+}
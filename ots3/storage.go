@@ -0,0 +1,34 @@
+package ots3
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// Storage is implemented by anything capable of storing and retrieving
+// blobs. It lets callers swap the backing object store (S3, local
+// filesystem, ...) without changing call sites.
+type Storage interface {
+	// Upload stores the content read from r under name and returns a URL
+	// that can be used to retrieve it.
+	Upload(ctx context.Context, name string, r io.Reader) (string, error)
+	// Download opens the object stored under name. The caller must close
+	// the returned reader.
+	Download(ctx context.Context, name string) (io.ReadCloser, error)
+	// Delete removes the object stored under name.
+	Delete(ctx context.Context, name string) error
+	// Stat returns metadata about the object stored under name.
+	Stat(ctx context.Context, name string) (Info, error)
+	// Presign returns a time-limited URL for method ("GET" or "PUT")
+	// against the object stored under name.
+	Presign(ctx context.Context, method string, name string, expires time.Duration) (string, error)
+}
+
+// Info describes a stored object.
+type Info struct {
+	Name         string
+	Size         int64
+	ContentType  string
+	LastModified time.Time
+}
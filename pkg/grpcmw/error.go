@@ -0,0 +1,87 @@
+// Package grpcmw converts the srverr sentinel errors used across services
+// to and from google.golang.org/grpc/codes, so gRPC transports preserve
+// the same semantic error kind that pkg/kitmw preserves for HTTP.
+package grpcmw
+
+import (
+	"github.com/DoNewsCode/std/pkg/srverr"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// codeForKind maps a srverr.Kind onto the gRPC status code that best
+// represents it.
+func codeForKind(kind srverr.Kind) codes.Code {
+	switch kind {
+	case srverr.KindNotFound:
+		return codes.NotFound
+	case srverr.KindInvalidArgument:
+		return codes.InvalidArgument
+	case srverr.KindPermissionDenied:
+		return codes.PermissionDenied
+	case srverr.KindUnauthenticated:
+		return codes.Unauthenticated
+	case srverr.KindDeadlineExceeded:
+		return codes.DeadlineExceeded
+	case srverr.KindUnavailable:
+		return codes.Unavailable
+	case srverr.KindInternal:
+		return codes.Internal
+	default:
+		return codes.Unknown
+	}
+}
+
+// kindForCode is the inverse of codeForKind, used to reconstruct a
+// srverr.ServerError from a gRPC status received from another service.
+func kindForCode(code codes.Code) srverr.Kind {
+	switch code {
+	case codes.NotFound:
+		return srverr.KindNotFound
+	case codes.InvalidArgument:
+		return srverr.KindInvalidArgument
+	case codes.PermissionDenied:
+		return srverr.KindPermissionDenied
+	case codes.Unauthenticated:
+		return srverr.KindUnauthenticated
+	case codes.DeadlineExceeded:
+		return srverr.KindDeadlineExceeded
+	case codes.Unavailable:
+		return srverr.KindUnavailable
+	case codes.Internal:
+		return srverr.KindInternal
+	default:
+		return srverr.Unknown
+	}
+}
+
+// ToStatus converts err into a *status.Status, unwrapping it to find the
+// first srverr.ServerError in its chain (see package srverr for the
+// sentinel constructors). An error that does not wrap a ServerError is
+// reported as codes.Internal, matching
+// kitmw.MakeErrorMarshallerMiddleware's behavior for HTTP.
+func ToStatus(err error) *status.Status {
+	if err == nil {
+		return status.New(codes.OK, "")
+	}
+	se, ok := srverr.As(err)
+	if !ok {
+		return status.New(codes.Internal, err.Error())
+	}
+	st := status.New(codeForKind(se.Kind()), se.Error())
+	if withDetails, derr := st.WithDetails(&errdetails.ErrorInfo{Reason: se.Kind().String()}); derr == nil {
+		return withDetails
+	}
+	return st
+}
+
+// FromStatus reconstructs a srverr.ServerError from a *status.Status
+// received from another service, so the semantic error kind survives a
+// cross-service call. It returns nil for an OK status.
+func FromStatus(st *status.Status) error {
+	if st == nil || st.Code() == codes.OK {
+		return nil
+	}
+	return srverr.New(kindForCode(st.Code()), st.Err(), "")
+}
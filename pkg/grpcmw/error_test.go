@@ -0,0 +1,43 @@
+package grpcmw
+
+import (
+	"testing"
+
+	"github.com/DoNewsCode/std/pkg/srverr"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+)
+
+func TestToStatus(t *testing.T) {
+	cases := []struct {
+		name string
+		err  error
+		code codes.Code
+	}{
+		{"plain error", errors.New("foo"), codes.Internal},
+		{"not found", srverr.NotFoundErr(errors.New("bar"), ""), codes.NotFound},
+		{"nested server error", srverr.NotFoundErr(srverr.InvalidArgumentErr(errors.New("bar"), ""), ""), codes.NotFound},
+		{"wrapped server error", errors.Wrap(srverr.NotFoundErr(errors.New("foo"), ""), "bar"), codes.NotFound},
+	}
+	for _, c := range cases {
+		cc := c
+		t.Run(cc.name, func(t *testing.T) {
+			st := ToStatus(cc.err)
+			if st.Code() != cc.code {
+				t.Fatalf("got code %s, want %s", st.Code(), cc.code)
+			}
+		})
+	}
+}
+
+func TestFromStatus(t *testing.T) {
+	st := ToStatus(srverr.NotFoundErr(errors.New("bar"), ""))
+	err := FromStatus(st)
+	se, ok := srverr.As(err)
+	if !ok {
+		t.Fatal("expected a srverr.ServerError")
+	}
+	if se.Kind() != srverr.KindNotFound {
+		t.Fatalf("got kind %v, want %v", se.Kind(), srverr.KindNotFound)
+	}
+}
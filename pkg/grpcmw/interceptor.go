@@ -0,0 +1,33 @@
+package grpcmw
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// UnaryServerInterceptor converts any error returned by the handler into
+// the gRPC status that best represents its srverr.Kind, preserving the
+// semantic error type across the wire.
+func UnaryServerInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	resp, err := handler(ctx, req)
+	if err != nil {
+		return resp, ToStatus(err).Err()
+	}
+	return resp, nil
+}
+
+// UnaryClientInterceptor reverses UnaryServerInterceptor: it converts the
+// gRPC status returned by the server back into a srverr.ServerError, so
+// the caller can type-assert on the same sentinel errors used server-side.
+func UnaryClientInterceptor(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	err := invoker(ctx, method, req, reply, cc, opts...)
+	if err == nil {
+		return nil
+	}
+	if st, ok := status.FromError(err); ok {
+		return FromStatus(st)
+	}
+	return err
+}
@@ -0,0 +1,31 @@
+// Package kitmw collects go-kit endpoint.Middleware implementations shared
+// across services.
+package kitmw
+
+import (
+	"context"
+
+	"github.com/DoNewsCode/std/pkg/srverr"
+	"github.com/go-kit/kit/endpoint"
+)
+
+// MakeErrorMarshallerMiddleware converts any error returned by the wrapped
+// endpoint into a srverr.ServerError, so the transport layer always has a
+// semantic error kind to map onto a status code. An error that already is,
+// or wraps, a srverr.ServerError is returned as that ServerError, with any
+// wrapping around it dropped; any other error is wrapped as an internal
+// error.
+func MakeErrorMarshallerMiddleware() endpoint.Middleware {
+	return func(next endpoint.Endpoint) endpoint.Endpoint {
+		return func(ctx context.Context, request interface{}) (interface{}, error) {
+			response, err := next(ctx, request)
+			if err == nil {
+				return response, nil
+			}
+			if se, ok := srverr.As(err); ok {
+				return response, se
+			}
+			return response, srverr.InternalErr(err, "")
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package otredis
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/DoNewsCode/std/pkg/config"
@@ -12,6 +13,138 @@ import (
 	"github.com/opentracing/opentracing-go"
 )
 
+// Conf is the configuration for a single named redis entry. In addition to
+// the fields of redis.UniversalOptions, it accepts a connection string
+// (URL) and an explicit TLS block, both layered on top of UniversalOptions
+// since some settings (e.g. a *tls.Config) cannot be expressed as plain
+// text.
+type Conf struct {
+	redis.UniversalOptions `mapstructure:",squash"`
+
+	// URL, when set, is parsed by ParseConnString and merged into the
+	// UniversalOptions above, taking precedence over any field also set
+	// directly. Supported schemes: redis://, rediss://, redis-sentinel://,
+	// redis-cluster://.
+	URL string `json:"url"`
+
+	// TLS configures transport security beyond what rediss:// implies,
+	// such as client certificates or a custom CA.
+	TLS TLSConf `json:"tls"`
+}
+
+// buildOptions resolves conf into a *redis.UniversalOptions, applying URL
+// and TLS on top of the directly configured fields, and validates the
+// result.
+func buildOptions(conf Conf) (*redis.UniversalOptions, error) {
+	opts := conf.UniversalOptions
+
+	if conf.URL != "" {
+		parsed, err := ParseConnString(conf.URL)
+		if err != nil {
+			return nil, err
+		}
+		mergeOptions(&opts, parsed)
+	}
+
+	if conf.TLS.Enabled {
+		tlsConfig, err := conf.TLS.buildTLSConfig()
+		if err != nil {
+			return nil, err
+		}
+		opts.TLSConfig = tlsConfig
+	}
+
+	if err := validateOptions(&opts); err != nil {
+		return nil, err
+	}
+
+	return &opts, nil
+}
+
+// mergeOptions overlays onto opts every field parsed from a connection
+// string, leaving any field the connection string left at its zero value
+// untouched — so a directly configured field (e.g. PoolSize) survives
+// when the URL doesn't express it. Boolean fields are the one exception:
+// since false is indistinguishable from "not set", a URL with
+// read_only=false cannot clear a directly configured true.
+func mergeOptions(opts, parsed *redis.UniversalOptions) {
+	if len(parsed.Addrs) > 0 {
+		opts.Addrs = parsed.Addrs
+	}
+	if parsed.Username != "" {
+		opts.Username = parsed.Username
+	}
+	if parsed.Password != "" {
+		opts.Password = parsed.Password
+	}
+	if parsed.DB != 0 {
+		opts.DB = parsed.DB
+	}
+	if parsed.MasterName != "" {
+		opts.MasterName = parsed.MasterName
+	}
+	if parsed.SentinelPassword != "" {
+		opts.SentinelPassword = parsed.SentinelPassword
+	}
+	if parsed.TLSConfig != nil {
+		opts.TLSConfig = parsed.TLSConfig
+	}
+	if parsed.DialTimeout != 0 {
+		opts.DialTimeout = parsed.DialTimeout
+	}
+	if parsed.ReadTimeout != 0 {
+		opts.ReadTimeout = parsed.ReadTimeout
+	}
+	if parsed.WriteTimeout != 0 {
+		opts.WriteTimeout = parsed.WriteTimeout
+	}
+	if parsed.PoolTimeout != 0 {
+		opts.PoolTimeout = parsed.PoolTimeout
+	}
+	if parsed.IdleTimeout != 0 {
+		opts.IdleTimeout = parsed.IdleTimeout
+	}
+	if parsed.MinRetryBackoff != 0 {
+		opts.MinRetryBackoff = parsed.MinRetryBackoff
+	}
+	if parsed.MaxRetryBackoff != 0 {
+		opts.MaxRetryBackoff = parsed.MaxRetryBackoff
+	}
+	if parsed.MaxRetries != 0 {
+		opts.MaxRetries = parsed.MaxRetries
+	}
+	if parsed.PoolSize != 0 {
+		opts.PoolSize = parsed.PoolSize
+	}
+	if parsed.MinIdleConns != 0 {
+		opts.MinIdleConns = parsed.MinIdleConns
+	}
+	if parsed.MaxRedirects != 0 {
+		opts.MaxRedirects = parsed.MaxRedirects
+	}
+	if parsed.ReadOnly {
+		opts.ReadOnly = parsed.ReadOnly
+	}
+	if parsed.RouteByLatency {
+		opts.RouteByLatency = parsed.RouteByLatency
+	}
+	if parsed.RouteRandomly {
+		opts.RouteRandomly = parsed.RouteRandomly
+	}
+}
+
+// validateOptions catches configuration combinations go-redis would
+// otherwise fail on only once a command is issued.
+func validateOptions(opts *redis.UniversalOptions) error {
+	if len(opts.Addrs) == 0 {
+		return fmt.Errorf("otredis: no addrs configured")
+	}
+	if opts.SentinelPassword != "" && opts.MasterName == "" {
+		return fmt.Errorf("otredis: sentinelPassword requires masterName to be set")
+	}
+	return nil
+}
+
 // RedisConfigurationInterceptor intercepts the redis.UniversalOptions before
 // creating the client so you can make amendment to it. Useful because some
 // configuration can not be mapped to a text representation. For example, you
@@ -43,19 +176,21 @@ type RedisOut struct {
 // dependency for package core.
 func Provide(p RedisIn) (RedisOut, func()) {
 	var err error
-	var dbConfs map[string]redis.UniversalOptions
+	var dbConfs map[string]Conf
 	err = p.Conf.Unmarshal("redis", &dbConfs)
 	if err != nil {
 		level.Warn(p.Logger).Log("err", err)
 	}
 	factory := di.NewFactory(func(name string) (di.Pair, error) {
-		var (
-			ok   bool
-			conf redis.UniversalOptions
-		)
-		if conf, ok = dbConfs[name]; !ok {
+		rawConf, ok := dbConfs[name]
+		if !ok {
 			return di.Pair{}, fmt.Errorf("redis configuration %s not valid", name)
 		}
+		opts, err := buildOptions(rawConf)
+		if err != nil {
+			return di.Pair{}, fmt.Errorf("redis configuration %s: %w", name, err)
+		}
+		conf := *opts
 		if p.Interceptor != nil {
 			p.Interceptor(name, &conf)
 		}
@@ -91,6 +226,9 @@ func Provide(p RedisIn) (RedisOut, func()) {
 // Maker is models Factory
 type Maker interface {
 	Make(name string) (redis.UniversalClient, error)
+	// Health pings the redis.UniversalClient created for name, so callers
+	// can plug it into readiness probes.
+	Health(ctx context.Context, name string) error
 }
 
 // Factory is a *di.Factory that creates redis.UniversalClient using a
@@ -108,6 +246,15 @@ func (r Factory) Make(name string) (redis.UniversalClient, error) {
 	return client.(redis.UniversalClient), nil
 }
 
+// Health pings the redis.UniversalClient created for name.
+func (r Factory) Health(ctx context.Context, name string) error {
+	client, err := r.Make(name)
+	if err != nil {
+		return err
+	}
+	return client.Ping(ctx).Err()
+}
+
 // provideConfig exports the default redis configuration
 func provideConfig() []config.ExportedConfig {
 	return []config.ExportedConfig{
@@ -138,6 +285,15 @@ func provideConfig() []config.ExportedConfig {
 						"routeByLatency":     false,
 						"routeRandomly":      false,
 						"masterName":         "",
+						"url":                "",
+						"tls": map[string]interface{}{
+							"enabled":            false,
+							"caFile":             "",
+							"certFile":           "",
+							"keyFile":            "",
+							"insecureSkipVerify": false,
+							"serverName":         "",
+						},
 					},
 				},
 			},
@@ -0,0 +1,82 @@
+package otredis
+
+import (
+	"testing"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildOptions(t *testing.T) {
+	t.Run("url merges onto direct fields instead of replacing them", func(t *testing.T) {
+		conf := Conf{
+			UniversalOptions: redis.UniversalOptions{
+				PoolSize:    42,
+				ReadTimeout: 5,
+			},
+			URL: "redis://localhost:6379/1",
+		}
+		opts, err := buildOptions(conf)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"localhost:6379"}, opts.Addrs)
+		assert.Equal(t, 1, opts.DB)
+		assert.Equal(t, 42, opts.PoolSize, "direct field not expressible in the URL must survive")
+		assert.EqualValues(t, 5, opts.ReadTimeout)
+	})
+
+	t.Run("url field overrides the same direct field", func(t *testing.T) {
+		conf := Conf{
+			UniversalOptions: redis.UniversalOptions{
+				Addrs: []string{"old:6379"},
+				DB:    9,
+			},
+			URL: "redis://new:6379/2",
+		}
+		opts, err := buildOptions(conf)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"new:6379"}, opts.Addrs)
+		assert.Equal(t, 2, opts.DB)
+	})
+
+	t.Run("tls.Enabled sets TLSConfig on top of the merged options", func(t *testing.T) {
+		conf := Conf{
+			UniversalOptions: redis.UniversalOptions{Addrs: []string{"localhost:6379"}},
+			TLS:              TLSConf{Enabled: true, ServerName: "example.com"},
+		}
+		opts, err := buildOptions(conf)
+		assert.NoError(t, err)
+		assert.NotNil(t, opts.TLSConfig)
+		assert.Equal(t, "example.com", opts.TLSConfig.ServerName)
+	})
+
+	t.Run("invalid url is rejected", func(t *testing.T) {
+		_, err := buildOptions(Conf{URL: "redis://host:notaport?dial_timeout=notaduration"})
+		assert.Error(t, err)
+	})
+}
+
+func TestValidateOptions(t *testing.T) {
+	t.Run("no addrs", func(t *testing.T) {
+		assert.Error(t, validateOptions(&redis.UniversalOptions{}))
+	})
+
+	t.Run("sentinelPassword without masterName", func(t *testing.T) {
+		assert.Error(t, validateOptions(&redis.UniversalOptions{
+			Addrs:            []string{"localhost:6379"},
+			SentinelPassword: "secret",
+		}))
+	})
+
+	t.Run("username without password is allowed for a nopass ACL user", func(t *testing.T) {
+		assert.NoError(t, validateOptions(&redis.UniversalOptions{
+			Addrs:    []string{"localhost:6379"},
+			Username: "user",
+		}))
+	})
+
+	t.Run("valid config", func(t *testing.T) {
+		assert.NoError(t, validateOptions(&redis.UniversalOptions{
+			Addrs: []string{"localhost:6379"},
+		}))
+	})
+}
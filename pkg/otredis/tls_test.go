@@ -0,0 +1,32 @@
+package otredis
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTLSConfBuildTLSConfig(t *testing.T) {
+	t.Run("disabled returns nil", func(t *testing.T) {
+		cfg, err := TLSConf{Enabled: false}.buildTLSConfig()
+		assert.NoError(t, err)
+		assert.Nil(t, cfg)
+	})
+
+	t.Run("enabled without files", func(t *testing.T) {
+		cfg, err := TLSConf{Enabled: true, ServerName: "example.com", InsecureSkipVerify: true}.buildTLSConfig()
+		assert.NoError(t, err)
+		assert.Equal(t, "example.com", cfg.ServerName)
+		assert.True(t, cfg.InsecureSkipVerify)
+	})
+
+	t.Run("missing ca file errors", func(t *testing.T) {
+		_, err := TLSConf{Enabled: true, CAFile: "/does/not/exist.pem"}.buildTLSConfig()
+		assert.Error(t, err)
+	})
+
+	t.Run("missing cert file errors", func(t *testing.T) {
+		_, err := TLSConf{Enabled: true, CertFile: "/does/not/exist.pem", KeyFile: "/does/not/exist.key"}.buildTLSConfig()
+		assert.Error(t, err)
+	})
+}
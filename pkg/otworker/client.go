@@ -0,0 +1,80 @@
+package otworker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// TaskInfo describes a task that has been accepted by Enqueue.
+type TaskInfo struct {
+	ID    string
+	Queue string
+}
+
+// Client enqueues tasks onto Redis-backed queues for a Server to process.
+type Client struct {
+	rdb redis.UniversalClient
+}
+
+// NewClient creates a Client that talks to Redis through rdb.
+func NewClient(rdb redis.UniversalClient) *Client {
+	return &Client{rdb: rdb}
+}
+
+// Enqueue schedules task for processing, applying opts such as Queue,
+// ProcessIn, Retry, Timeout, Deadline and Unique.
+func (c *Client) Enqueue(ctx context.Context, task *Task, opts ...TaskOption) (*TaskInfo, error) {
+	o := newTaskOptions(opts...)
+
+	if o.uniqueTTL > 0 {
+		ok, err := c.rdb.SetNX(ctx, uniqueKey(task), "1", o.uniqueTTL).Result()
+		if err != nil {
+			return nil, fmt.Errorf("otworker: check task uniqueness: %w", err)
+		}
+		if !ok {
+			return nil, fmt.Errorf("otworker: task is not unique")
+		}
+	}
+
+	msg := message{
+		ID:       newTaskID(),
+		Type:     task.Type,
+		Payload:  task.Payload,
+		Queue:    o.queue,
+		TimeoutS: int64(o.timeout / time.Second),
+		MaxRetry: o.maxRetry,
+	}
+	if !o.deadline.IsZero() {
+		msg.Deadline = o.deadline.Unix()
+	}
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return nil, fmt.Errorf("otworker: marshal task: %w", err)
+	}
+
+	if !o.processAt.IsZero() && o.processAt.After(time.Now()) {
+		// The score is rounded up to a whole second, since the Server
+		// compares it against the current time truncated to seconds.
+		score := o.processAt.Unix()
+		if o.processAt.Nanosecond() > 0 {
+			score++
+		}
+		if err := c.rdb.ZAdd(ctx, scheduledKey(o.queue), &redis.Z{
+			Score:  float64(score),
+			Member: data,
+		}).Err(); err != nil {
+			return nil, fmt.Errorf("otworker: schedule task: %w", err)
+		}
+		return &TaskInfo{ID: msg.ID, Queue: o.queue}, nil
+	}
+
+	if err := c.rdb.LPush(ctx, pendingKey(o.queue), data).Err(); err != nil {
+		return nil, fmt.Errorf("otworker: enqueue task: %w", err)
+	}
+	enqueuedCounter.WithLabelValues(o.queue, task.Type).Inc()
+	return &TaskInfo{ID: msg.ID, Queue: o.queue}, nil
+}
@@ -0,0 +1,87 @@
+package otworker
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestRedis(t *testing.T) redis.UniversalClient {
+	t.Helper()
+	s, err := miniredis.Run()
+	assert.NoError(t, err)
+	t.Cleanup(s.Close)
+	return redis.NewClient(&redis.Options{Addr: s.Addr()})
+}
+
+func TestClientEnqueue(t *testing.T) {
+	t.Run("lands on the pending list by default", func(t *testing.T) {
+		rdb := newTestRedis(t)
+		client := NewClient(rdb)
+
+		info, err := client.Enqueue(context.Background(), NewTask("greet", []byte("hi")))
+		assert.NoError(t, err)
+		assert.Equal(t, "default", info.Queue)
+
+		n, err := rdb.LLen(context.Background(), pendingKey("default")).Result()
+		assert.NoError(t, err)
+		assert.EqualValues(t, 1, n)
+	})
+
+	t.Run("ProcessAt in the future lands on the scheduled set", func(t *testing.T) {
+		rdb := newTestRedis(t)
+		client := NewClient(rdb)
+
+		_, err := client.Enqueue(context.Background(), NewTask("greet", nil), ProcessIn(time.Hour))
+		assert.NoError(t, err)
+
+		pendingN, _ := rdb.LLen(context.Background(), pendingKey("default")).Result()
+		assert.EqualValues(t, 0, pendingN)
+
+		scheduledN, _ := rdb.ZCard(context.Background(), scheduledKey("default")).Result()
+		assert.EqualValues(t, 1, scheduledN)
+	})
+
+	t.Run("Queue routes to a named queue", func(t *testing.T) {
+		rdb := newTestRedis(t)
+		client := NewClient(rdb)
+
+		_, err := client.Enqueue(context.Background(), NewTask("greet", nil), Queue("critical"))
+		assert.NoError(t, err)
+
+		n, _ := rdb.LLen(context.Background(), pendingKey("critical")).Result()
+		assert.EqualValues(t, 1, n)
+	})
+
+	t.Run("Unique rejects a duplicate before its TTL elapses", func(t *testing.T) {
+		rdb := newTestRedis(t)
+		client := NewClient(rdb)
+		task := NewTask("greet", []byte("hi"))
+
+		_, err := client.Enqueue(context.Background(), task, Unique(time.Minute))
+		assert.NoError(t, err)
+
+		_, err = client.Enqueue(context.Background(), task, Unique(time.Minute))
+		assert.Error(t, err)
+	})
+
+	t.Run("encodes retry and timeout onto the message", func(t *testing.T) {
+		rdb := newTestRedis(t)
+		client := NewClient(rdb)
+
+		_, err := client.Enqueue(context.Background(), NewTask("greet", nil), Retry(3), Timeout(5*time.Second))
+		assert.NoError(t, err)
+
+		data, err := rdb.LIndex(context.Background(), pendingKey("default"), 0).Result()
+		assert.NoError(t, err)
+		var msg message
+		assert.NoError(t, json.Unmarshal([]byte(data), &msg))
+		assert.Equal(t, 3, msg.MaxRetry)
+		assert.EqualValues(t, 5, msg.TimeoutS)
+	})
+}
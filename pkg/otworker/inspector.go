@@ -0,0 +1,143 @@
+package otworker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// TaskState is the lifecycle stage of an inspected task.
+type TaskState string
+
+// Task states as returned by Inspector.
+const (
+	TaskStatePending   TaskState = "pending"
+	TaskStateScheduled TaskState = "scheduled"
+	TaskStateRetry     TaskState = "retry"
+	TaskStateArchived  TaskState = "archived"
+)
+
+// TaskInspection describes a task as seen by Inspector, including the
+// bookkeeping fields not exposed through Task.
+type TaskInspection struct {
+	ID       string
+	Type     string
+	Payload  []byte
+	Queue    string
+	State    TaskState
+	Retried  int
+	MaxRetry int
+	LastErr  string
+	// NextProcessAt is non-zero for scheduled and retry tasks. For archived
+	// tasks it holds the time the task was archived; for pending tasks it
+	// is zero.
+	NextProcessAt time.Time
+}
+
+// Inspector lists and manages tasks sitting in a queue's pending,
+// scheduled, retry and archived sets.
+type Inspector struct {
+	rdb redis.UniversalClient
+}
+
+// NewInspector creates an Inspector that operates through rdb.
+func NewInspector(rdb redis.UniversalClient) *Inspector {
+	return &Inspector{rdb: rdb}
+}
+
+// ListPending returns the tasks waiting to be picked up on queue.
+func (i *Inspector) ListPending(ctx context.Context, queue string) ([]*TaskInspection, error) {
+	data, err := i.rdb.LRange(ctx, pendingKey(queue), 0, -1).Result()
+	if err != nil {
+		return nil, fmt.Errorf("otworker: list pending tasks on %s: %w", queue, err)
+	}
+	return decodeList(data, queue, TaskStatePending, nil)
+}
+
+// ListScheduled returns the tasks on queue waiting for their ProcessAt time.
+func (i *Inspector) ListScheduled(ctx context.Context, queue string) ([]*TaskInspection, error) {
+	return i.listZSet(ctx, scheduledKey(queue), queue, TaskStateScheduled)
+}
+
+// ListRetry returns the tasks on queue waiting to be retried.
+func (i *Inspector) ListRetry(ctx context.Context, queue string) ([]*TaskInspection, error) {
+	return i.listZSet(ctx, retryKey(queue), queue, TaskStateRetry)
+}
+
+// ListArchived returns the tasks on queue that exhausted their retry
+// budget or deadline.
+func (i *Inspector) ListArchived(ctx context.Context, queue string) ([]*TaskInspection, error) {
+	return i.listZSet(ctx, archivedKey(queue), queue, TaskStateArchived)
+}
+
+func (i *Inspector) listZSet(ctx context.Context, key, queue string, state TaskState) ([]*TaskInspection, error) {
+	results, err := i.rdb.ZRangeWithScores(ctx, key, 0, -1).Result()
+	if err != nil {
+		return nil, fmt.Errorf("otworker: list %s tasks on %s: %w", state, queue, err)
+	}
+	data := make([]string, len(results))
+	scores := make([]float64, len(results))
+	for idx, z := range results {
+		data[idx], _ = z.Member.(string)
+		scores[idx] = z.Score
+	}
+	return decodeList(data, queue, state, scores)
+}
+
+func decodeList(data []string, queue string, state TaskState, scores []float64) ([]*TaskInspection, error) {
+	out := make([]*TaskInspection, 0, len(data))
+	for idx, raw := range data {
+		var msg message
+		if err := json.Unmarshal([]byte(raw), &msg); err != nil {
+			continue
+		}
+		ti := &TaskInspection{
+			ID:       msg.ID,
+			Type:     msg.Type,
+			Payload:  msg.Payload,
+			Queue:    queue,
+			State:    state,
+			Retried:  msg.Retried,
+			MaxRetry: msg.MaxRetry,
+			LastErr:  msg.LastErr,
+		}
+		if scores != nil {
+			ti.NextProcessAt = time.Unix(int64(scores[idx]), 0)
+		}
+		out = append(out, ti)
+	}
+	return out, nil
+}
+
+// RequeueArchived moves every archived task on queue back onto the
+// pending list, resetting its retry count.
+func (i *Inspector) RequeueArchived(ctx context.Context, queue string) (int, error) {
+	data, err := i.rdb.ZRange(ctx, archivedKey(queue), 0, -1).Result()
+	if err != nil {
+		return 0, fmt.Errorf("otworker: list archived tasks on %s: %w", queue, err)
+	}
+	var requeued int
+	for _, raw := range data {
+		var msg message
+		if err := json.Unmarshal([]byte(raw), &msg); err != nil {
+			continue
+		}
+		msg.Retried = 0
+		msg.LastErr = ""
+		newData, err := json.Marshal(msg)
+		if err != nil {
+			continue
+		}
+		if removed, err := i.rdb.ZRem(ctx, archivedKey(queue), raw).Result(); err != nil || removed == 0 {
+			continue
+		}
+		if err := i.rdb.LPush(ctx, pendingKey(queue), newData).Err(); err != nil {
+			return requeued, fmt.Errorf("otworker: requeue task %s: %w", msg.ID, err)
+		}
+		requeued++
+	}
+	return requeued, nil
+}
@@ -0,0 +1,59 @@
+package otworker
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInspector(t *testing.T) {
+	t.Run("lists pending and scheduled tasks", func(t *testing.T) {
+		rdb := newTestRedis(t)
+		client := NewClient(rdb)
+		inspector := NewInspector(rdb)
+
+		_, err := client.Enqueue(context.Background(), NewTask("greet", []byte("hi")))
+		assert.NoError(t, err)
+		_, err = client.Enqueue(context.Background(), NewTask("greet", nil), ProcessIn(time.Hour))
+		assert.NoError(t, err)
+
+		pending, err := inspector.ListPending(context.Background(), "default")
+		assert.NoError(t, err)
+		assert.Len(t, pending, 1)
+		assert.Equal(t, TaskStatePending, pending[0].State)
+
+		scheduled, err := inspector.ListScheduled(context.Background(), "default")
+		assert.NoError(t, err)
+		assert.Len(t, scheduled, 1)
+		assert.Equal(t, TaskStateScheduled, scheduled[0].State)
+		assert.False(t, scheduled[0].NextProcessAt.IsZero())
+	})
+
+	t.Run("RequeueArchived moves archived tasks back onto pending and resets retries", func(t *testing.T) {
+		rdb := newTestRedis(t)
+		inspector := NewInspector(rdb)
+
+		msg := message{ID: "t1", Type: "greet", Queue: "default", Retried: 3, MaxRetry: 3, LastErr: "boom"}
+		data, err := json.Marshal(msg)
+		assert.NoError(t, err)
+		assert.NoError(t, rdb.ZAdd(context.Background(), archivedKey("default"), &redis.Z{Score: 1, Member: data}).Err())
+
+		n, err := inspector.RequeueArchived(context.Background(), "default")
+		assert.NoError(t, err)
+		assert.Equal(t, 1, n)
+
+		archived, err := inspector.ListArchived(context.Background(), "default")
+		assert.NoError(t, err)
+		assert.Len(t, archived, 0)
+
+		pending, err := inspector.ListPending(context.Background(), "default")
+		assert.NoError(t, err)
+		assert.Len(t, pending, 1)
+		assert.Equal(t, 0, pending[0].Retried)
+		assert.Empty(t, pending[0].LastErr)
+	})
+}
@@ -0,0 +1,36 @@
+package otworker
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+)
+
+// Keys are namespaced per queue using a hash tag ("{queue}") so that, under
+// Redis Cluster, every key belonging to a queue lands on the same slot and
+// can be moved between lists/sorted-sets atomically.
+
+func pendingKey(queue string) string {
+	return fmt.Sprintf("otworker:{%s}:pending", queue)
+}
+
+func processingKey(queue, workerID string) string {
+	return fmt.Sprintf("otworker:{%s}:processing:%s", queue, workerID)
+}
+
+func scheduledKey(queue string) string {
+	return fmt.Sprintf("otworker:{%s}:scheduled", queue)
+}
+
+func retryKey(queue string) string {
+	return fmt.Sprintf("otworker:{%s}:retry", queue)
+}
+
+func archivedKey(queue string) string {
+	return fmt.Sprintf("otworker:{%s}:archived", queue)
+}
+
+func uniqueKey(task *Task) string {
+	sum := sha256.Sum256(append([]byte(task.Type+":"), task.Payload...))
+	return fmt.Sprintf("otworker:unique:%s", hex.EncodeToString(sum[:]))
+}
@@ -0,0 +1,31 @@
+package otworker
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+)
+
+// message is the wire representation of a Task once it is enqueued. It
+// carries the bookkeeping fields (queue, retry budget, ...) the Server
+// needs that Task itself does not expose to callers.
+type message struct {
+	ID       string `json:"id"`
+	Type     string `json:"type"`
+	Payload  []byte `json:"payload"`
+	Queue    string `json:"queue"`
+	TimeoutS int64  `json:"timeout"`  // seconds; 0 means no per-attempt timeout
+	Deadline int64  `json:"deadline"` // unix seconds; 0 means no deadline
+	MaxRetry int    `json:"maxRetry"`
+	Retried  int    `json:"retried"`
+	LastErr  string `json:"lastErr,omitempty"`
+}
+
+func (m *message) task() *Task {
+	return &Task{Type: m.Type, Payload: m.Payload}
+}
+
+func newTaskID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
@@ -0,0 +1,32 @@
+package otworker
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	enqueuedCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "otworker",
+		Name:      "enqueued_total",
+		Help:      "Total tasks enqueued, by queue and task type.",
+	}, []string{"queue", "type"})
+
+	processedCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "otworker",
+		Name:      "processed_total",
+		Help:      "Total tasks processed successfully, by queue and task type.",
+	}, []string{"queue", "type"})
+
+	failedCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "otworker",
+		Name:      "failed_total",
+		Help:      "Total task processing attempts that returned an error, by queue and task type.",
+	}, []string{"queue", "type"})
+
+	processingLatency = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "otworker",
+		Name:      "processing_latency_seconds",
+		Help:      "Latency of a single task processing attempt, by queue and task type.",
+	}, []string{"queue", "type"})
+)
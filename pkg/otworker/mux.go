@@ -0,0 +1,55 @@
+package otworker
+
+import (
+	"context"
+	"fmt"
+)
+
+// Handler processes a single Task.
+type Handler interface {
+	ProcessTask(ctx context.Context, task *Task) error
+}
+
+// HandlerFunc adapts a plain function to a Handler.
+type HandlerFunc func(ctx context.Context, task *Task) error
+
+// ProcessTask calls f(ctx, task).
+func (f HandlerFunc) ProcessTask(ctx context.Context, task *Task) error {
+	return f(ctx, task)
+}
+
+// ServeMux routes a Task to the Handler registered for its Type, mirroring
+// net/http.ServeMux.
+type ServeMux struct {
+	handlers map[string]Handler
+}
+
+// NewServeMux creates an empty ServeMux.
+func NewServeMux() *ServeMux {
+	return &ServeMux{handlers: make(map[string]Handler)}
+}
+
+// Handle registers handler for taskType. It panics if taskType is already
+// registered.
+func (m *ServeMux) Handle(taskType string, handler Handler) {
+	if _, exists := m.handlers[taskType]; exists {
+		panic(fmt.Sprintf("otworker: handler already registered for task type %q", taskType))
+	}
+	m.handlers[taskType] = handler
+}
+
+// HandleFunc registers fn for taskType.
+func (m *ServeMux) HandleFunc(taskType string, fn func(ctx context.Context, task *Task) error) {
+	m.Handle(taskType, HandlerFunc(fn))
+}
+
+// ProcessTask dispatches task to the Handler registered for its Type.
+func (m *ServeMux) ProcessTask(ctx context.Context, task *Task) error {
+	h, ok := m.handlers[task.Type]
+	if !ok {
+		return fmt.Errorf("otworker: no handler registered for task type %q", task.Type)
+	}
+	return h.ProcessTask(ctx, task)
+}
+
+var _ Handler = (*ServeMux)(nil)
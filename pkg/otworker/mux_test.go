@@ -0,0 +1,44 @@
+package otworker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServeMux(t *testing.T) {
+	t.Run("dispatches to the registered handler", func(t *testing.T) {
+		mux := NewServeMux()
+		var got *Task
+		mux.HandleFunc("greet", func(ctx context.Context, task *Task) error {
+			got = task
+			return nil
+		})
+
+		task := NewTask("greet", []byte("hi"))
+		assert.NoError(t, mux.ProcessTask(context.Background(), task))
+		assert.Equal(t, task, got)
+	})
+
+	t.Run("unregistered type errors", func(t *testing.T) {
+		mux := NewServeMux()
+		err := mux.ProcessTask(context.Background(), NewTask("unknown", nil))
+		assert.Error(t, err)
+	})
+
+	t.Run("duplicate registration panics", func(t *testing.T) {
+		mux := NewServeMux()
+		mux.HandleFunc("greet", func(ctx context.Context, task *Task) error { return nil })
+		assert.Panics(t, func() {
+			mux.HandleFunc("greet", func(ctx context.Context, task *Task) error { return nil })
+		})
+	})
+}
+
+func TestBackoff(t *testing.T) {
+	assert.Equal(t, 2*time.Second, backoff(1))
+	assert.Equal(t, 4*time.Second, backoff(2))
+	assert.Equal(t, time.Hour, backoff(20), "backoff must cap at one hour")
+}
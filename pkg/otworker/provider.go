@@ -0,0 +1,79 @@
+package otworker
+
+import (
+	"github.com/DoNewsCode/std/pkg/config"
+	"github.com/DoNewsCode/std/pkg/contract"
+	"github.com/DoNewsCode/std/pkg/di"
+	"github.com/DoNewsCode/std/pkg/otredis"
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/opentracing/opentracing-go"
+)
+
+// workerConf is the shape of the "otworker" configuration key.
+type workerConf struct {
+	// Redis names the otredis configuration entry the queue is stored in.
+	Redis string `json:"redis"`
+}
+
+// WorkerIn is the injection parameter for Provide.
+type WorkerIn struct {
+	di.In
+
+	Logger log.Logger
+	Conf   contract.ConfigAccessor
+	Redis  otredis.Maker
+	Tracer opentracing.Tracer `optional:"true"`
+}
+
+// WorkerOut is the result of Provide.
+type WorkerOut struct {
+	di.Out
+
+	Client         *Client
+	Inspector      *Inspector
+	ExportedConfig []config.ExportedConfig `group:"config,flatten"`
+}
+
+// Provide creates a Client and Inspector wired to the redis connection
+// named by the "otworker" configuration key. It is a valid dependency for
+// package core.
+//
+// Provide only wires the producer side (Client, Inspector); a Server is
+// long-running and is constructed directly with NewServer by whichever
+// binary is meant to process tasks.
+func Provide(p WorkerIn) (WorkerOut, func()) {
+	var conf workerConf
+	if err := p.Conf.Unmarshal("otworker", &conf); err != nil {
+		level.Warn(p.Logger).Log("err", err)
+	}
+	if conf.Redis == "" {
+		conf.Redis = "default"
+	}
+
+	rdb, err := p.Redis.Make(conf.Redis)
+	if err != nil {
+		level.Warn(p.Logger).Log("err", err)
+	}
+
+	return WorkerOut{
+		Client:         NewClient(rdb),
+		Inspector:      NewInspector(rdb),
+		ExportedConfig: provideConfig(),
+	}, func() {}
+}
+
+// provideConfig exports the default otworker configuration.
+func provideConfig() []config.ExportedConfig {
+	return []config.ExportedConfig{
+		{
+			Owner: "otworker",
+			Data: map[string]interface{}{
+				"otworker": map[string]interface{}{
+					"redis": "default",
+				},
+			},
+			Comment: "The configuration of the distributed task queue",
+		},
+	}
+}
@@ -0,0 +1,282 @@
+package otworker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/go-redis/redis/v8"
+	"github.com/opentracing/opentracing-go"
+)
+
+// ServerConfig configures a Server.
+type ServerConfig struct {
+	// Queues lists the queues to pull tasks from, in priority order: the
+	// first queue is always drained before the next is considered. It
+	// defaults to []string{"default"}.
+	Queues []string
+	// Concurrency is how many tasks are processed in parallel. It defaults
+	// to 10.
+	Concurrency int
+	// WorkerID identifies this server's processing lists, so a restarted
+	// server can reclaim tasks left behind by a crash. It defaults to the
+	// host's hostname, which is stable across restarts; set it explicitly
+	// if more than one Server on Queues shares a hostname.
+	WorkerID string
+	// ForwardInterval is how often scheduled and retry tasks whose time has
+	// come are moved onto their pending queue. It defaults to one second.
+	ForwardInterval time.Duration
+
+	Logger log.Logger
+	Tracer opentracing.Tracer
+}
+
+// Server pulls tasks from one or more priority queues and dispatches them
+// to a Handler, retrying failed tasks with exponential backoff and
+// archiving tasks that exceed their retry budget.
+type Server struct {
+	rdb    redis.UniversalClient
+	conf   ServerConfig
+	logger log.Logger
+}
+
+// NewServer creates a Server that processes tasks fetched through rdb.
+func NewServer(rdb redis.UniversalClient, conf ServerConfig) *Server {
+	if len(conf.Queues) == 0 {
+		conf.Queues = []string{"default"}
+	}
+	if conf.Concurrency <= 0 {
+		conf.Concurrency = 10
+	}
+	if conf.WorkerID == "" {
+		if hostname, err := os.Hostname(); err == nil {
+			conf.WorkerID = hostname
+		} else {
+			conf.WorkerID = newTaskID()
+		}
+	}
+	if conf.ForwardInterval <= 0 {
+		conf.ForwardInterval = time.Second
+	}
+	logger := conf.Logger
+	if logger == nil {
+		logger = log.NewNopLogger()
+	}
+	return &Server{rdb: rdb, conf: conf, logger: logger}
+}
+
+// Run starts processing tasks with handler until ctx is cancelled. Before
+// dispatching new work, it reclaims any tasks left in its own processing
+// lists by a previous run that crashed without acknowledging them.
+func (s *Server) Run(ctx context.Context, handler Handler) error {
+	s.reclaim(ctx)
+
+	var wg sync.WaitGroup
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		s.forward(ctx)
+	}()
+
+	for i := 0; i < s.conf.Concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.worker(ctx, handler)
+		}()
+	}
+
+	wg.Wait()
+	return nil
+}
+
+// worker repeatedly pulls one task off s.conf.Queues (in priority order)
+// and runs it through handler.
+func (s *Server) worker(ctx context.Context, handler Handler) {
+	for {
+		if ctx.Err() != nil {
+			return
+		}
+		msg, err := s.dequeue(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			level.Warn(s.logger).Log("err", err)
+			continue
+		}
+		if msg == nil {
+			continue
+		}
+		s.process(ctx, msg, handler)
+	}
+}
+
+// reclaim moves every task still sitting in s.conf.WorkerID's processing
+// lists back onto its queue's pending list. It runs once at startup so a
+// server that crashed mid-task, then restarted with the same WorkerID,
+// resumes those tasks instead of losing them.
+func (s *Server) reclaim(ctx context.Context) {
+	for _, queue := range s.conf.Queues {
+		key := processingKey(queue, s.conf.WorkerID)
+		for {
+			if _, err := s.rdb.RPopLPush(ctx, key, pendingKey(queue)).Result(); err != nil {
+				if err != redis.Nil {
+					level.Warn(s.logger).Log("err", fmt.Errorf("otworker: reclaim stale tasks on %s: %w", queue, err))
+				}
+				break
+			}
+		}
+	}
+}
+
+// dequeue tries each configured queue in priority order with a short
+// blocking pop, moving the popped message into a per-worker processing
+// list for at-least-once semantics.
+func (s *Server) dequeue(ctx context.Context) (*message, error) {
+	for _, queue := range s.conf.Queues {
+		data, err := s.rdb.BRPopLPush(ctx, pendingKey(queue), processingKey(queue, s.conf.WorkerID), 100*time.Millisecond).Result()
+		if err == redis.Nil {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("otworker: dequeue from %s: %w", queue, err)
+		}
+		var msg message
+		if err := json.Unmarshal([]byte(data), &msg); err != nil {
+			_ = s.rdb.LRem(ctx, processingKey(queue, s.conf.WorkerID), 1, data).Err()
+			return nil, fmt.Errorf("otworker: decode task from %s: %w", queue, err)
+		}
+		return &msg, nil
+	}
+	return nil, nil
+}
+
+// process runs a single task through handler, recording metrics/tracing,
+// and either acknowledges, retries, or archives it based on the outcome.
+func (s *Server) process(ctx context.Context, msg *message, handler Handler) {
+	taskCtx := ctx
+	var cancel context.CancelFunc
+	if msg.TimeoutS > 0 {
+		taskCtx, cancel = context.WithTimeout(ctx, time.Duration(msg.TimeoutS)*time.Second)
+		defer cancel()
+	}
+
+	var span opentracing.Span
+	if s.conf.Tracer != nil {
+		span, taskCtx = opentracing.StartSpanFromContextWithTracer(taskCtx, s.conf.Tracer, "otworker.ProcessTask")
+		span.SetTag("queue", msg.Queue)
+		span.SetTag("type", msg.Type)
+		defer span.Finish()
+	}
+
+	start := time.Now()
+	err := handler.ProcessTask(taskCtx, msg.task())
+	processingLatency.WithLabelValues(msg.Queue, msg.Type).Observe(time.Since(start).Seconds())
+
+	data, marshalErr := json.Marshal(msg)
+	if marshalErr != nil {
+		level.Warn(s.logger).Log("err", marshalErr)
+	}
+	_ = s.rdb.LRem(ctx, processingKey(msg.Queue, s.conf.WorkerID), 1, data).Err()
+
+	if err == nil {
+		processedCounter.WithLabelValues(msg.Queue, msg.Type).Inc()
+		return
+	}
+
+	failedCounter.WithLabelValues(msg.Queue, msg.Type).Inc()
+	if span != nil {
+		span.SetTag("error", true)
+	}
+	s.retryOrArchive(ctx, msg, err)
+}
+
+// retryOrArchive reschedules msg with exponential backoff, unless its
+// retry budget or deadline has been exceeded, in which case it is moved to
+// the archive.
+func (s *Server) retryOrArchive(ctx context.Context, msg *message, taskErr error) {
+	msg.Retried++
+	msg.LastErr = taskErr.Error()
+
+	exceededDeadline := msg.Deadline > 0 && time.Now().Unix() >= msg.Deadline
+	exceededRetry := msg.Retried > msg.MaxRetry
+
+	data, err := json.Marshal(msg)
+	if err != nil {
+		level.Warn(s.logger).Log("err", err)
+		return
+	}
+
+	if exceededDeadline || exceededRetry {
+		if err := s.rdb.ZAdd(ctx, archivedKey(msg.Queue), &redis.Z{
+			Score:  float64(time.Now().Unix()),
+			Member: data,
+		}).Err(); err != nil {
+			level.Warn(s.logger).Log("err", err)
+		}
+		return
+	}
+
+	next := time.Now().Add(backoff(msg.Retried))
+	if err := s.rdb.ZAdd(ctx, retryKey(msg.Queue), &redis.Z{
+		Score:  float64(next.Unix()),
+		Member: data,
+	}).Err(); err != nil {
+		level.Warn(s.logger).Log("err", err)
+	}
+}
+
+// backoff returns the delay before the n-th retry attempt, growing
+// exponentially and capped at one hour.
+func backoff(n int) time.Duration {
+	d := time.Duration(math.Pow(2, float64(n))) * time.Second
+	if max := time.Hour; d > max {
+		d = max
+	}
+	return d
+}
+
+// forward periodically moves scheduled and retry tasks whose time has come
+// onto their pending queue.
+func (s *Server) forward(ctx context.Context) {
+	ticker := time.NewTicker(s.conf.ForwardInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			for _, queue := range s.conf.Queues {
+				s.forwardDue(ctx, scheduledKey(queue), pendingKey(queue))
+				s.forwardDue(ctx, retryKey(queue), pendingKey(queue))
+			}
+		}
+	}
+}
+
+// forwardDue moves every member of the sorted set at fromKey with a score
+// at or before now onto the list at toKey.
+func (s *Server) forwardDue(ctx context.Context, fromKey, toKey string) {
+	now := float64(time.Now().Unix())
+	due, err := s.rdb.ZRangeByScore(ctx, fromKey, &redis.ZRangeBy{Min: "-inf", Max: fmt.Sprintf("%f", now)}).Result()
+	if err != nil || len(due) == 0 {
+		return
+	}
+	for _, member := range due {
+		if removed, err := s.rdb.ZRem(ctx, fromKey, member).Result(); err != nil || removed == 0 {
+			// Another server already claimed this member.
+			continue
+		}
+		if err := s.rdb.LPush(ctx, toKey, member).Err(); err != nil {
+			level.Warn(s.logger).Log("err", err)
+		}
+	}
+}
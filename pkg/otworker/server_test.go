@@ -0,0 +1,142 @@
+package otworker
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServerRun(t *testing.T) {
+	t.Run("processes a pending task and removes it from the processing list", func(t *testing.T) {
+		rdb := newTestRedis(t)
+		client := NewClient(rdb)
+		_, err := client.Enqueue(context.Background(), NewTask("greet", []byte("hi")))
+		assert.NoError(t, err)
+
+		server := NewServer(rdb, ServerConfig{WorkerID: "w1", ForwardInterval: 10 * time.Millisecond})
+		done := make(chan struct{}, 1)
+		mux := NewServeMux()
+		mux.HandleFunc("greet", func(ctx context.Context, task *Task) error {
+			done <- struct{}{}
+			return nil
+		})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		go server.Run(ctx, mux)
+		defer cancel()
+
+		select {
+		case <-done:
+		case <-time.After(2 * time.Second):
+			t.Fatal("expected handler to run")
+		}
+
+		time.Sleep(100 * time.Millisecond)
+		n, _ := rdb.LLen(context.Background(), processingKey("default", "w1")).Result()
+		assert.EqualValues(t, 0, n)
+	})
+
+	t.Run("a failed task is scheduled for retry", func(t *testing.T) {
+		rdb := newTestRedis(t)
+		client := NewClient(rdb)
+		_, err := client.Enqueue(context.Background(), NewTask("fail", nil), Retry(5))
+		assert.NoError(t, err)
+
+		server := NewServer(rdb, ServerConfig{WorkerID: "w1", ForwardInterval: 10 * time.Millisecond})
+		attempted := make(chan struct{}, 1)
+		mux := NewServeMux()
+		mux.HandleFunc("fail", func(ctx context.Context, task *Task) error {
+			attempted <- struct{}{}
+			return errors.New("boom")
+		})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		go server.Run(ctx, mux)
+		defer cancel()
+
+		select {
+		case <-attempted:
+		case <-time.After(2 * time.Second):
+			t.Fatal("expected handler to run")
+		}
+
+		assert.Eventually(t, func() bool {
+			n, _ := rdb.ZCard(context.Background(), retryKey("default")).Result()
+			return n == 1
+		}, 2*time.Second, 20*time.Millisecond)
+	})
+
+	t.Run("a task that exhausts its retry budget is archived", func(t *testing.T) {
+		rdb := newTestRedis(t)
+		client := NewClient(rdb)
+		_, err := client.Enqueue(context.Background(), NewTask("fail", nil), Retry(0))
+		assert.NoError(t, err)
+
+		server := NewServer(rdb, ServerConfig{WorkerID: "w1", ForwardInterval: 10 * time.Millisecond})
+		mux := NewServeMux()
+		mux.HandleFunc("fail", func(ctx context.Context, task *Task) error {
+			return errors.New("boom")
+		})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		go server.Run(ctx, mux)
+		defer cancel()
+
+		assert.Eventually(t, func() bool {
+			n, _ := rdb.ZCard(context.Background(), archivedKey("default")).Result()
+			return n == 1
+		}, 2*time.Second, 20*time.Millisecond)
+	})
+
+	t.Run("Run reclaims tasks stranded in its own processing list by a prior crash", func(t *testing.T) {
+		rdb := newTestRedis(t)
+		stranded := `{"id":"stale","type":"greet","queue":"default"}`
+		assert.NoError(t, rdb.LPush(context.Background(), processingKey("default", "w1"), stranded).Err())
+
+		done := make(chan struct{}, 1)
+		server := NewServer(rdb, ServerConfig{WorkerID: "w1", ForwardInterval: 10 * time.Millisecond})
+		mux := NewServeMux()
+		mux.HandleFunc("greet", func(ctx context.Context, task *Task) error {
+			done <- struct{}{}
+			return nil
+		})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		go server.Run(ctx, mux)
+		defer cancel()
+
+		select {
+		case <-done:
+		case <-time.After(2 * time.Second):
+			t.Fatal("expected the stranded task to be reclaimed and processed")
+		}
+	})
+
+	t.Run("forward moves a due scheduled task onto pending", func(t *testing.T) {
+		rdb := newTestRedis(t)
+		client := NewClient(rdb)
+		_, err := client.Enqueue(context.Background(), NewTask("greet", nil), ProcessIn(50*time.Millisecond))
+		assert.NoError(t, err)
+
+		done := make(chan struct{}, 1)
+		server := NewServer(rdb, ServerConfig{WorkerID: "w1", ForwardInterval: 10 * time.Millisecond})
+		mux := NewServeMux()
+		mux.HandleFunc("greet", func(ctx context.Context, task *Task) error {
+			done <- struct{}{}
+			return nil
+		})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		go server.Run(ctx, mux)
+		defer cancel()
+
+		select {
+		case <-done:
+		case <-time.After(2 * time.Second):
+			t.Fatal("expected the scheduled task to be forwarded and processed")
+		}
+	})
+}
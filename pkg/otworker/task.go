@@ -0,0 +1,83 @@
+// Package otworker layers a reliable, Redis-backed task queue on top of
+// pkg/otredis, modeled after asynq: a Client enqueues typed tasks, and a
+// Server pulls them off one or more priority queues and dispatches them to
+// registered Handlers with at-least-once delivery.
+package otworker
+
+import "time"
+
+// Task is a unit of work to be processed asynchronously.
+type Task struct {
+	Type    string
+	Payload []byte
+}
+
+// NewTask creates a Task of the given type carrying payload.
+func NewTask(typ string, payload []byte) *Task {
+	return &Task{Type: typ, Payload: payload}
+}
+
+// TaskOption configures how a Task is enqueued.
+type TaskOption func(*taskOptions)
+
+type taskOptions struct {
+	queue     string
+	processAt time.Time
+	maxRetry  int
+	timeout   time.Duration
+	deadline  time.Time
+	uniqueTTL time.Duration
+}
+
+// defaultMaxRetry mirrors asynq's default retry budget.
+const defaultMaxRetry = 25
+
+func newTaskOptions(opts ...TaskOption) taskOptions {
+	o := taskOptions{
+		queue:    "default",
+		maxRetry: defaultMaxRetry,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// Queue sets the queue a task is enqueued onto. It defaults to "default".
+func Queue(name string) TaskOption {
+	return func(o *taskOptions) { o.queue = name }
+}
+
+// ProcessAt schedules a task to be processed no earlier than t.
+func ProcessAt(t time.Time) TaskOption {
+	return func(o *taskOptions) { o.processAt = t }
+}
+
+// ProcessIn schedules a task to be processed no earlier than d from now.
+func ProcessIn(d time.Duration) TaskOption {
+	return func(o *taskOptions) { o.processAt = time.Now().Add(d) }
+}
+
+// Retry sets how many times a failed task is retried before it is
+// archived. It defaults to 25.
+func Retry(n int) TaskOption {
+	return func(o *taskOptions) { o.maxRetry = n }
+}
+
+// Timeout bounds how long a single attempt at processing the task may run
+// before it is considered stuck and returned to the queue for retry.
+func Timeout(d time.Duration) TaskOption {
+	return func(o *taskOptions) { o.timeout = d }
+}
+
+// Deadline sets an absolute time after which the task is archived instead
+// of retried, regardless of the remaining retry budget.
+func Deadline(t time.Time) TaskOption {
+	return func(o *taskOptions) { o.deadline = t }
+}
+
+// Unique ensures that no task with the same type and payload can be
+// enqueued again until ttl has elapsed.
+func Unique(ttl time.Duration) TaskOption {
+	return func(o *taskOptions) { o.uniqueTTL = ttl }
+}
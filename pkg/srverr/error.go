@@ -0,0 +1,141 @@
+// Package srverr defines the sentinel error kinds used to signal a
+// semantic failure (not found, invalid argument, ...) from a service layer
+// up through middleware to a transport, without the transport needing to
+// know anything about the underlying cause.
+package srverr
+
+import "errors"
+
+// Kind enumerates the semantic error categories srverr understands. Each
+// has a natural mapping onto both HTTP status codes and gRPC codes.
+type Kind int
+
+// The error kinds srverr's constructors produce.
+const (
+	Unknown Kind = iota
+	KindNotFound
+	KindInvalidArgument
+	KindPermissionDenied
+	KindUnauthenticated
+	KindDeadlineExceeded
+	KindUnavailable
+	KindInternal
+)
+
+// String implements fmt.Stringer.
+func (k Kind) String() string {
+	switch k {
+	case KindNotFound:
+		return "not_found"
+	case KindInvalidArgument:
+		return "invalid_argument"
+	case KindPermissionDenied:
+		return "permission_denied"
+	case KindUnauthenticated:
+		return "unauthenticated"
+	case KindDeadlineExceeded:
+		return "deadline_exceeded"
+	case KindUnavailable:
+		return "unavailable"
+	case KindInternal:
+		return "internal"
+	default:
+		return "unknown"
+	}
+}
+
+// ServerError is implemented by every sentinel error this package
+// constructs. It carries the semantic error kind alongside the wrapped
+// cause and an optional user-facing message.
+type ServerError interface {
+	error
+
+	// Unwrap returns the wrapped error, so errors.Is/errors.As and
+	// errors.Unwrap see through a ServerError.
+	Unwrap() error
+	// Kind reports which sentinel this error represents.
+	Kind() Kind
+	// Message is the user-facing description of the error. It may be
+	// empty, in which case Error() falls back to the wrapped cause.
+	Message() string
+}
+
+type serverError struct {
+	cause   error
+	kind    Kind
+	message string
+}
+
+func (e *serverError) Error() string {
+	if e.message != "" {
+		return e.message
+	}
+	return e.cause.Error()
+}
+
+func (e *serverError) Unwrap() error   { return e.cause }
+func (e *serverError) Kind() Kind      { return e.kind }
+func (e *serverError) Message() string { return e.message }
+
+// New wraps cause as a ServerError of the given kind. It is mainly useful
+// to reconstruct a ServerError from something other than a Go error, such
+// as a gRPC status code; callers with a plain error should prefer the
+// kind-specific constructors below.
+func New(kind Kind, cause error, message string) error {
+	return &serverError{cause: cause, kind: kind, message: message}
+}
+
+// NotFoundErr wraps cause as a ServerError indicating the requested
+// resource does not exist.
+func NotFoundErr(cause error, message string) error {
+	return New(KindNotFound, cause, message)
+}
+
+// InvalidArgumentErr wraps cause as a ServerError indicating the caller
+// supplied an invalid argument.
+func InvalidArgumentErr(cause error, message string) error {
+	return New(KindInvalidArgument, cause, message)
+}
+
+// PermissionDeniedErr wraps cause as a ServerError indicating the caller
+// lacks permission to perform the operation.
+func PermissionDeniedErr(cause error, message string) error {
+	return New(KindPermissionDenied, cause, message)
+}
+
+// UnauthenticatedErr wraps cause as a ServerError indicating the caller
+// could not be authenticated.
+func UnauthenticatedErr(cause error, message string) error {
+	return New(KindUnauthenticated, cause, message)
+}
+
+// DeadlineExceededErr wraps cause as a ServerError indicating the
+// operation did not complete in time.
+func DeadlineExceededErr(cause error, message string) error {
+	return New(KindDeadlineExceeded, cause, message)
+}
+
+// UnavailableErr wraps cause as a ServerError indicating a dependency was
+// temporarily unavailable.
+func UnavailableErr(cause error, message string) error {
+	return New(KindUnavailable, cause, message)
+}
+
+// InternalErr wraps cause as a ServerError indicating an unexpected
+// internal failure.
+func InternalErr(cause error, message string) error {
+	return New(KindInternal, cause, message)
+}
+
+// As walks err's Unwrap chain looking for a ServerError, returning it and
+// true if one is found. It lets callers see through both a plain
+// *serverError and one further wrapped by e.g. github.com/pkg/errors.Wrap.
+func As(err error) (ServerError, bool) {
+	for err != nil {
+		if se, ok := err.(ServerError); ok {
+			return se, true
+		}
+		err = errors.Unwrap(err)
+	}
+	return nil, false
+}